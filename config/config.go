@@ -0,0 +1,257 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-ai-agent/core/io2"
+	"github.com/go-ai-agent/core/runtime"
+	strings2 "github.com/go-ai-agent/core/strings"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	NameTag     = "config"
+	DefaultTag  = "default"
+	RequiredOpt = "required"
+	SkipName    = "-"
+)
+
+var (
+	bindLocation    = PkgUri + "/Bind"
+	loadLocation    = PkgUri + "/Load"
+	loadUrlLocation = PkgUri + "/LoadUrl"
+	durationType    = reflect.TypeOf(time.Duration(0))
+	envNames        = []string{runtime.DebugEnv, runtime.TestEnv, runtime.StageEnv, runtime.ProdEnv}
+)
+
+// Overlay - create a map for an environment. Keys prefixed with an environment name and a ".", such as
+// "prod.timeout", replace the unprefixed key for that environment, and are removed for all other environments.
+func Overlay(m map[string]string, env string) map[string]string {
+	result := make(map[string]string)
+	for k, v := range m {
+		if _, ok := envKey(k); !ok {
+			result[k] = v
+		}
+	}
+	for k, v := range m {
+		if name, ok := envKey(k); ok && strings.HasPrefix(k, env+".") {
+			result[name] = v
+		}
+	}
+	return result
+}
+
+func envKey(key string) (string, bool) {
+	for _, env := range envNames {
+		if strings.HasPrefix(key, env+".") {
+			return key[len(env)+1:], true
+		}
+	}
+	return "", false
+}
+
+// Bind - bind a map onto a tagged struct, after applying the overlay for the current environment. Fields are
+// named by the "config" tag, or the lower case field name, and a "-" name skips the field. A "required" tag
+// option requires a value, and the "default" tag provides the value when the key is missing or empty. Values
+// starting with "$" are expanded from the environment. All errors are returned in the status.
+//
+//	type Config struct {
+//		Host    string        `config:"host,required"`
+//		Timeout time.Duration `config:"timeout" default:"5s"`
+//	}
+func Bind(m map[string]string, t any) *runtime.Status {
+	var errs []error
+
+	v := reflect.ValueOf(t)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return runtime.NewStatusError(runtime.StatusInvalidArgument, bindLocation, errors.New(fmt.Sprintf("invalid argument: type is not a struct pointer [%v]", reflect.TypeOf(t))))
+	}
+	m = Overlay(m, runtime.EnvStr())
+	v = v.Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, required := parseTag(field)
+		if name == SkipName {
+			continue
+		}
+		s, err := lookup(m, name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if s == "" {
+			s = field.Tag.Get(DefaultTag)
+		}
+		if s == "" {
+			if required {
+				errs = append(errs, errors.New(fmt.Sprintf("config error: value is required [%v]", name)))
+			}
+			continue
+		}
+		if err = setValue(v.Field(i), s); err != nil {
+			errs = append(errs, errors.New(fmt.Sprintf("config error: value is invalid [%v] [%v]", name, err)))
+		}
+	}
+	if len(errs) > 0 {
+		return runtime.NewStatusError(runtime.StatusInvalidArgument, bindLocation, errs...)
+	}
+	return runtime.NewStatusOK()
+}
+
+// Load - bind text, either JSON or "key: value" lines, onto a tagged struct
+func Load(buf []byte, t any) *runtime.Status {
+	m, err := parse(buf)
+	if err != nil {
+		return runtime.NewStatusError(runtime.StatusInvalidContent, loadLocation, err)
+	}
+	return Bind(m, t)
+}
+
+// LoadUrl - bind a file, read via io2.ReadFile, onto a tagged struct
+func LoadUrl(u *url.URL, t any) *runtime.Status {
+	buf, err := io2.ReadFile(u)
+	if err != nil {
+		return runtime.NewStatusError(runtime.StatusIOError, loadUrlLocation, err)
+	}
+	return Load(buf, t)
+}
+
+// parse - create a map from JSON, or from "key: value" lines. JSON environment objects are flattened into
+// prefixed keys, and other JSON values that are not strings are kept as JSON.
+func parse(buf []byte) (map[string]string, error) {
+	buf = bytes.TrimSpace(buf)
+	if len(buf) == 0 || buf[0] != '{' {
+		return strings2.TextToMap(buf)
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &raw); err != nil {
+		return nil, err
+	}
+	m := make(map[string]string)
+	for k, v := range raw {
+		if isEnvName(k) && len(v) > 0 && v[0] == '{' {
+			sub, err := parse(v)
+			if err != nil {
+				return nil, err
+			}
+			for k2, v2 := range sub {
+				m[k+"."+k2] = v2
+			}
+			continue
+		}
+		var s string
+		if json.Unmarshal(v, &s) == nil {
+			m[k] = s
+		} else if string(v) != "null" {
+			m[k] = string(v)
+		}
+	}
+	return m, nil
+}
+
+func isEnvName(s string) bool {
+	for _, env := range envNames {
+		if s == env {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTag(field reflect.StructField) (name string, required bool) {
+	tokens := strings.Split(field.Tag.Get(NameTag), ",")
+	name = strings.TrimSpace(tokens[0])
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	for _, opt := range tokens[1:] {
+		if strings.TrimSpace(opt) == RequiredOpt {
+			required = true
+		}
+	}
+	return
+}
+
+func lookup(m map[string]string, name string) (string, error) {
+	s := m[name]
+	if strings.HasPrefix(s, runtime.EnvPrefix) {
+		v, err := runtime.LookupEnv(s)
+		if err != nil {
+			return "", errors.New(fmt.Sprintf("config error: environment variable is invalid [%v] [%v]", name, err))
+		}
+		return v, nil
+	}
+	return s, nil
+}
+
+func setValue(v reflect.Value, s string) error {
+	if v.Type() == durationType {
+		d, err := parseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.String && !strings.HasPrefix(s, "[") {
+			var list []string
+			for _, item := range strings.Split(s, ",") {
+				list = append(list, strings.TrimSpace(item))
+			}
+			v.Set(reflect.ValueOf(list).Convert(v.Type()))
+			return nil
+		}
+		return json.Unmarshal([]byte(s), v.Addr().Interface())
+	case reflect.Map, reflect.Struct:
+		return json.Unmarshal([]byte(s), v.Addr().Interface())
+	default:
+		return errors.New(fmt.Sprintf("type is not supported [%v]", v.Type()))
+	}
+	return nil
+}
+
+// parseDuration - parse via strings.ParseDuration, falling back to time.ParseDuration for values such as "1h30m"
+func parseDuration(s string) (time.Duration, error) {
+	d, err := strings2.ParseDuration(s)
+	if err == nil {
+		return d, nil
+	}
+	return time.ParseDuration(s)
+}
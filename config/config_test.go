@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"net/url"
+	"os"
+	"time"
+)
+
+type serviceConfig struct {
+	Host    string        `config:"host,required"`
+	Port    int           `config:"port" default:"80"`
+	Timeout time.Duration `config:"timeout" default:"5s"`
+	Debug   bool          `default:"false"`
+	Tags    []string      `config:"tags"`
+	User    string        `config:"user"`
+	Limits  struct {
+		Rate  float64 `json:"rate"`
+		Burst int     `json:"burst"`
+	} `config:"limits"`
+	Ignore string `config:"-"`
+}
+
+func ExampleOverlay() {
+	m := map[string]string{"host": "localhost", "prod.host": "api.example.com", "stage.port": "9090", "port": "80"}
+
+	fmt.Printf("test: Overlay(debug) -> %v\n", Overlay(m, runtime.DebugEnv))
+	fmt.Printf("test: Overlay(prod) -> %v\n", Overlay(m, runtime.ProdEnv))
+	fmt.Printf("test: Overlay(stage) -> %v\n", Overlay(m, runtime.StageEnv))
+
+	//Output:
+	//test: Overlay(debug) -> map[host:localhost port:80]
+	//test: Overlay(prod) -> map[host:api.example.com port:80]
+	//test: Overlay(stage) -> map[host:localhost port:9090]
+
+}
+
+func ExampleBind() {
+	var cfg serviceConfig
+
+	status := Bind(map[string]string{"port": "invalid", "timeout": "forever", "debug": "true"}, &cfg)
+	fmt.Printf("test: Bind(invalid) -> [status:%v] [debug:%v]\n", status, cfg.Debug)
+
+	status = Bind(map[string]string{"host": "localhost"}, cfg)
+	fmt.Printf("test: Bind(struct) -> [status:%v]\n", status)
+
+	cfg = serviceConfig{}
+	status = Bind(map[string]string{"host": "localhost", "tags": "a,b", "ignore": "value"}, &cfg)
+	fmt.Printf("test: Bind() -> [status:%v] [host:%v] [port:%v] [timeout:%v] [tags:%v] [ignore:%v]\n", status, cfg.Host, cfg.Port, cfg.Timeout, cfg.Tags, cfg.Ignore)
+
+	//Output:
+	//test: Bind(invalid) -> [status:Invalid Argument [config error: value is required [host] config error: value is invalid [port] [strconv.ParseInt: parsing "invalid": invalid syntax] config error: value is invalid [timeout] [time: invalid duration "forever"]]] [debug:true]
+	//test: Bind(struct) -> [status:Invalid Argument [invalid argument: type is not a struct pointer [config.serviceConfig]]]
+	//test: Bind() -> [status:OK] [host:localhost] [port:80] [timeout:5s] [tags:[a b]] [ignore:]
+
+}
+
+func ExampleLoadUrl() {
+	var cfg serviceConfig
+	defer runtime.SetEnvironment(runtime.DebugEnv)
+	os.Setenv("CONFIG_TEST_USER", "bob")
+	defer os.Unsetenv("CONFIG_TEST_USER")
+
+	u, _ := url.Parse("file:///configtest/resource/config.txt")
+	status := LoadUrl(u, &cfg)
+	fmt.Printf("test: LoadUrl(txt) -> [status:%v] [host:%v] [port:%v] [timeout:%v] [tags:%v] [user:%v]\n", status, cfg.Host, cfg.Port, cfg.Timeout, cfg.Tags, cfg.User)
+
+	runtime.SetEnvironment(runtime.ProdEnv)
+	cfg = serviceConfig{}
+	status = LoadUrl(u, &cfg)
+	fmt.Printf("test: LoadUrl(txt,prod) -> [status:%v] [host:%v] [timeout:%v]\n", status, cfg.Host, cfg.Timeout)
+
+	runtime.SetEnvironment(runtime.StageEnv)
+	cfg = serviceConfig{}
+	u, _ = url.Parse("file:///configtest/resource/config.json")
+	status = LoadUrl(u, &cfg)
+	fmt.Printf("test: LoadUrl(json,stage) -> [status:%v] [host:%v] [port:%v] [timeout:%v] [tags:%v] [limits:%v]\n", status, cfg.Host, cfg.Port, cfg.Timeout, cfg.Tags, cfg.Limits)
+
+	u, _ = url.Parse("file:///configtest/resource/invalid.json")
+	status = LoadUrl(u, &cfg)
+	fmt.Printf("test: LoadUrl(invalid) -> [status:%v]\n", status.Code())
+
+	//Output:
+	//test: LoadUrl(txt) -> [status:OK] [host:localhost] [port:8080] [timeout:750ms] [tags:[a b c]] [user:bob]
+	//test: LoadUrl(txt,prod) -> [status:OK] [host:api.example.com] [timeout:2s]
+	//test: LoadUrl(json,stage) -> [status:OK] [host:stage.example.com] [port:9090] [timeout:1h30m0s] [tags:[a b]] [limits:{100 10}]
+	//test: LoadUrl(invalid) -> [status:91]
+
+}
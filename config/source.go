@@ -0,0 +1,190 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/go-ai-agent/core/io2"
+	"github.com/go-ai-agent/core/runtime"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultReloadInterval = time.Second * 10
+)
+
+var reloadLocation = PkgUri + "/Source/Reload"
+
+// Validator - optional interface for a configuration type, called after binding
+type Validator interface {
+	Validate() error
+}
+
+// Source - hot reloadable configuration, read from a file:// url via io2.ReadFile. When started, the file is
+// polled and a changed file is loaded and validated into a new snapshot, which is swapped in and sent to all
+// subscribers, such as a resiliency controller updating a timeout, or a circuit breaker updating a limit. An
+// invalid file keeps the last good snapshot, and the failure is available via Status, and handled by the error
+// handler once, until the failure changes.
+type Source[E runtime.ErrorHandler, T any] struct {
+	u           *url.URL
+	interval    time.Duration
+	snapshot    T
+	buf         []byte
+	failed      []byte
+	status      *runtime.Status
+	subscribers []subscriber[T]
+	next        int
+	stop        chan struct{}
+	mu          sync.RWMutex
+}
+
+type subscriber[T any] struct {
+	id int
+	fn func(T)
+}
+
+// NewSource - templated function to create a source, the initial load must succeed
+func NewSource[E runtime.ErrorHandler, T any](u *url.URL, interval time.Duration) (*Source[E, T], *runtime.Status) {
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+	s := &Source[E, T]{u: u, interval: interval}
+	buf, t, status := s.load()
+	if !status.OK() {
+		return nil, status
+	}
+	s.buf = buf
+	s.snapshot = t
+	s.status = status
+	return s, status
+}
+
+// Get - the current snapshot
+func (s *Source[E, T]) Get() T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.snapshot
+}
+
+// Status - the status of the most recent load
+func (s *Source[E, T]) Status() *runtime.Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.status
+}
+
+// Subscribe - receive new snapshots, subscribers are notified in subscription order, and the returned function
+// cancels the subscription
+func (s *Source[E, T]) Subscribe(fn func(T)) (cancel func()) {
+	if fn == nil {
+		return func() {}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.next
+	s.next++
+	s.subscribers = append(s.subscribers, subscriber[T]{id: id, fn: fn})
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i, sub := range s.subscribers {
+			if sub.id == id {
+				s.subscribers = append(s.subscribers[:i:i], s.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Reload - load the file, and if it has changed and is valid, swap in the new snapshot and notify subscribers.
+// A failure is only handled by the error handler if it differs from the previous failure.
+func (s *Source[E, T]) Reload() *runtime.Status {
+	var e E
+
+	buf, t, status := s.load()
+	if !status.OK() {
+		// A file that cannot be read has no content, so the failure is identified by the error
+		if buf == nil {
+			buf = []byte(status.String())
+		}
+		s.mu.Lock()
+		s.status = status
+		repeated := s.failed != nil && bytes.Equal(buf, s.failed)
+		s.failed = buf
+		s.mu.Unlock()
+		if repeated {
+			return status
+		}
+		return e.Handle(status, "", reloadLocation)
+	}
+	s.mu.Lock()
+	s.status = status
+	s.failed = nil
+	if bytes.Equal(buf, s.buf) {
+		s.mu.Unlock()
+		return status
+	}
+	s.buf = buf
+	s.snapshot = t
+	subs := append([]subscriber[T](nil), s.subscribers...)
+	s.mu.Unlock()
+	for _, sub := range subs {
+		sub.fn(t)
+	}
+	return status
+}
+
+func (s *Source[E, T]) load() ([]byte, T, *runtime.Status) {
+	var t T
+
+	buf, err := io2.ReadFile(s.u)
+	if err != nil {
+		return nil, t, runtime.NewStatusError(runtime.StatusIOError, reloadLocation, err)
+	}
+	status := Load(buf, &t)
+	if !status.OK() {
+		return buf, t, status
+	}
+	if v, ok := any(&t).(Validator); ok {
+		if err = v.Validate(); err != nil {
+			return buf, t, runtime.NewStatusError(runtime.StatusInvalidArgument, reloadLocation, errors.New(fmt.Sprintf("config error: validation failed [%v]", err)))
+		}
+	}
+	return buf, t, status
+}
+
+// Start - poll the file for changes, a started source is not restarted
+func (s *Source[E, T]) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+	go s.run(s.stop)
+}
+
+// Stop - stop polling the file
+func (s *Source[E, T]) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+func (s *Source[E, T]) run(stop chan struct{}) {
+	tick := time.NewTicker(s.interval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+			s.Reload()
+		case <-stop:
+			return
+		}
+	}
+}
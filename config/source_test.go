@@ -0,0 +1,87 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+type limitsConfig struct {
+	Timeout time.Duration `config:"timeout,required"`
+	Limit   float64       `config:"limit" default:"100"`
+	Burst   int           `config:"burst" default:"10"`
+}
+
+func (c *limitsConfig) Validate() error {
+	if c.Burst <= 0 {
+		return errors.New(fmt.Sprintf("burst must be greater than 0 [%v]", c.Burst))
+	}
+	return nil
+}
+
+var handled atomic.Int32
+
+// countError - error handler counting the statuses handled
+type countError struct{}
+
+func (countError) Handle(s *runtime.Status, requestId string, location string) *runtime.Status {
+	handled.Add(1)
+	return s
+}
+
+func ExampleNewSource() {
+	dir, _ := os.MkdirTemp("", "config")
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "limits.txt")
+	u := &url.URL{Scheme: "file", Path: "/" + name}
+
+	_, status := NewSource[runtime.BypassError, limitsConfig](u, 0)
+	fmt.Printf("test: NewSource(missing) -> [status:%v]\n", status.Code())
+
+	os.WriteFile(name, []byte("timeout: 500ms\n"), 0600)
+	src, status := NewSource[countError, limitsConfig](u, time.Millisecond*20)
+	fmt.Printf("test: NewSource() -> [status:%v] [snapshot:%v]\n", status, src.Get())
+
+	var order []string
+	c := make(chan limitsConfig, 16)
+	for _, name := range []string{"first", "second", "third"} {
+		cancel := src.Subscribe(func(cfg limitsConfig) { order = append(order, name) })
+		if name == "second" {
+			cancel()
+		}
+	}
+	cancel := src.Subscribe(func(cfg limitsConfig) { c <- cfg })
+	defer cancel()
+	src.Start()
+	defer src.Stop()
+
+	writeFile(name, "timeout: 2s\nlimit: 50\nburst: 5\n")
+	fmt.Printf("test: Subscribe() -> [snapshot:%v] [order:%v]\n", <-c, order)
+
+	writeFile(name, "timeout: 2s\nburst: 0\n")
+	time.Sleep(time.Millisecond * 100)
+	fmt.Printf("test: Reload(invalid) -> [status:%v] [snapshot:%v] [notified:%v] [handled:%v]\n", src.Status(), src.Get(), len(c), handled.Load())
+
+	writeFile(name, "timeout: 3s\nburst: 0\n")
+	time.Sleep(time.Millisecond * 100)
+	fmt.Printf("test: Reload(changed) -> [handled:%v]\n", handled.Load())
+
+	//Output:
+	//test: NewSource(missing) -> [status:91]
+	//test: NewSource() -> [status:OK] [snapshot:{500ms 100 10}]
+	//test: Subscribe() -> [snapshot:{2s 50 5}] [order:[first third]]
+	//test: Reload(invalid) -> [status:Invalid Argument [config error: validation failed [burst must be greater than 0 [0]]]] [snapshot:{2s 50 5}] [notified:0] [handled:1]
+	//test: Reload(changed) -> [handled:2]
+
+}
+
+// writeFile - replace a file, so that a poll never reads a partially written file
+func writeFile(name, content string) {
+	os.WriteFile(name+".tmp", []byte(content), 0600)
+	os.Rename(name+".tmp", name)
+}
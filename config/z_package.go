@@ -0,0 +1,11 @@
+package config
+
+import (
+	"reflect"
+)
+
+type pkg struct{}
+
+var (
+	PkgUri = reflect.TypeOf(any(pkg{})).PkgPath()
+)
@@ -1,10 +1,14 @@
 module github.com/go-ai-agent/core
 
-go 1.18
-
-require github.com/google/uuid v1.3.0
+// The minimum Go version is raised by:
+//
+//	1.21 - log/slog, used by the runtime status sinks
+//	1.23 - iter, and range over functions, used by the http2 event and stream iterators
+go 1.23
 
 require (
-	github.com/felixge/httpsnoop v1.0.4 // indirect
-	golang.org/x/time v0.3.0 // indirect
+	github.com/felixge/httpsnoop v1.0.4
+	github.com/google/uuid v1.3.0
+	github.com/klauspost/compress v1.17.11
+	golang.org/x/time v0.3.0
 )
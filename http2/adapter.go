@@ -0,0 +1,124 @@
+package http2
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/felixge/httpsnoop"
+	"github.com/go-ai-agent/core/log2"
+	"github.com/go-ai-agent/core/runtime"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	ContentTypeProblemJson = "application/problem+json"
+	problemType            = "about:blank"
+)
+
+var adapterLoc = PkgUri + "/NewHandler"
+
+// Problem - RFC 9457 problem details body, written for a failing status when a handler has not written a response
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	RequestId string `json:"request-id,omitempty"`
+}
+
+// NewProblem - create a problem from a status
+func NewProblem(status *runtime.Status, r *http.Request) Problem {
+	code := StatusCode(status)
+	p := Problem{Type: problemType, Title: http.StatusText(code), Status: code, RequestId: status.RequestId()}
+	if err := status.FirstError(); err != nil {
+		p.Detail = err.Error()
+	}
+	if r != nil && r.URL != nil {
+		p.Instance = r.URL.Path
+	}
+	return p
+}
+
+// NewHandler - templated function adapting a runtime.HttpHandler to a http.Handler. The handler context contains
+// the request id. If the handler does not write a response, the status headers are copied, a successful status is
+// written with WriteNegotiated, and a client or server error status is written as a problem. Statuses with errors are
+// handled by the error handler, and all requests are logged as ingress access.
+func NewHandler[E runtime.ErrorHandler](h runtime.HttpHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e E
+		var start = time.Now().UTC()
+		var code = http.StatusOK
+		var written int64
+		var wrote = false
+
+		ctx := runtime.NewRequestContext(r)
+		w2 := httpsnoop.Wrap(w, httpsnoop.Hooks{
+			WriteHeader: func(next httpsnoop.WriteHeaderFunc) httpsnoop.WriteHeaderFunc {
+				return func(c int) {
+					if !wrote {
+						code = c
+						wrote = true
+					}
+					next(c)
+				}
+			},
+			Write: func(next httpsnoop.WriteFunc) httpsnoop.WriteFunc {
+				return func(b []byte) (int, error) {
+					wrote = true
+					n, err := next(b)
+					written += int64(n)
+					return n, err
+				}
+			},
+			ReadFrom: func(next httpsnoop.ReadFromFunc) httpsnoop.ReadFromFunc {
+				return func(src io.Reader) (int64, error) {
+					wrote = true
+					n, err := next(src)
+					written += n
+					return n, err
+				}
+			},
+		})
+		status := h(ctx, w2, r)
+		if status == nil {
+			status = runtime.NewStatusOK()
+		}
+		status.SetRequestId(ctx)
+		if status.IsErrors() {
+			status = e.Handle(status, runtime.RequestId(ctx), adapterLoc)
+		}
+		if !wrote {
+			status.CopyHeader(w2.Header())
+			if StatusCode(status) >= http.StatusBadRequest {
+				writeProblem[E](w2, NewProblem(status, r))
+			} else {
+				WriteNegotiated[E](w2, r, nil, status, nil)
+			}
+		}
+		flags := ""
+		if runtime.IsPanicStatus(status) {
+			flags = runtime.PanicFlag
+		}
+		log2.IngressAccess(start, time.Since(start), r, &http.Response{StatusCode: code, ContentLength: written}, -1, flags)
+	})
+}
+
+func writeProblem[E runtime.ErrorHandler](w http.ResponseWriter, p Problem) {
+	var e E
+
+	buf, err := json.Marshal(p)
+	if err != nil {
+		e.Handle(runtime.NewStatusError(runtime.StatusJsonEncodeError, adapterLoc, err), p.RequestId, "")
+		w.WriteHeader(p.Status)
+		return
+	}
+	w.Header().Set(ContentType, ContentTypeProblemJson)
+	w.Header().Set(ContentLength, fmt.Sprintf("%v", len(buf)))
+	w.WriteHeader(p.Status)
+	_, err = w.Write(buf)
+	if err != nil {
+		e.Handle(runtime.NewStatusError(http.StatusInternalServerError, adapterLoc, err), p.RequestId, "")
+	}
+}
@@ -0,0 +1,73 @@
+package http2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+// readFromRecorder - recorder implementing io.ReaderFrom, like the net/http response writer
+type readFromRecorder struct {
+	*httptest.ResponseRecorder
+}
+
+func (r *readFromRecorder) ReadFrom(src io.Reader) (int64, error) {
+	return io.Copy(r.ResponseRecorder, src)
+}
+
+func ExampleNewHandler() {
+	h := NewHandler[runtime.BypassError](func(ctx context.Context, w http.ResponseWriter, r *http.Request) *runtime.Status {
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, "request-id:%v", runtime.RequestId(ctx))
+		return runtime.NewStatusOK()
+	})
+	req := httptest.NewRequest(http.MethodGet, "/search", nil)
+	req.Header.Set(runtime.XRequestId, "123-456")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	fmt.Printf("test: NewHandler() -> [code:%v] [body:%v]\n", w.Code, w.Body.String())
+
+	h = NewHandler[runtime.BypassError](func(ctx context.Context, w http.ResponseWriter, r *http.Request) *runtime.Status {
+		return runtime.NewStatus(http.StatusNoContent)
+	})
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/search", nil))
+	fmt.Printf("test: NewHandler() -> [code:%v] [body:%v]\n", w.Code, w.Body.String())
+
+	h = NewHandler[runtime.BypassError](func(ctx context.Context, w http.ResponseWriter, r *http.Request) *runtime.Status {
+		// A reader without WriteTo, so that io.Copy uses ReadFrom as it does for a file
+		io.Copy(w, struct{ io.Reader }{strings.NewReader("streamed")})
+		return runtime.NewStatusError(runtime.StatusIOError, "/search", errors.New("stream interrupted"))
+	})
+	rf := &readFromRecorder{ResponseRecorder: httptest.NewRecorder()}
+	h.ServeHTTP(rf, httptest.NewRequest(http.MethodGet, "/search", nil))
+	fmt.Printf("test: NewHandler(ReadFrom) -> [code:%v] [body:%v]\n", rf.Code, rf.Body.String())
+
+	//Output:
+	//test: NewHandler() -> [code:202] [body:request-id:123-456]
+	//test: NewHandler() -> [code:204] [body:]
+	//test: NewHandler(ReadFrom) -> [code:200] [body:streamed]
+
+}
+
+func ExampleNewHandler_problem() {
+	h := NewHandler[runtime.BypassError](func(ctx context.Context, w http.ResponseWriter, r *http.Request) *runtime.Status {
+		return runtime.NewStatusError(runtime.StatusInvalidArgument, "/search", errors.New("query is empty"))
+	})
+	req := httptest.NewRequest(http.MethodGet, "/search", nil)
+	req.Header.Set(runtime.XRequestId, "123-456")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	fmt.Printf("test: NewHandler() -> [code:%v] [content-type:%v]\n", w.Code, w.Result().Header.Get(ContentType))
+	fmt.Printf("test: NewHandler() -> [body:%v]\n", w.Body.String())
+
+	//Output:
+	//test: NewHandler() -> [code:400] [content-type:application/problem+json]
+	//test: NewHandler() -> [body:{"type":"about:blank","title":"Bad Request","status":400,"detail":"query is empty","instance":"/search","request-id":"123-456"}]
+
+}
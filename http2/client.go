@@ -0,0 +1,293 @@
+package http2
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultClientTimeout       = time.Second * 5
+	DefaultMaxIdleConns        = 200
+	DefaultMaxIdleConnsPerHost = 100
+)
+
+var (
+	clientKey = &clientKeyType{}
+	clients   = make(map[string]*http.Client)
+	upstreams = make(map[string]string)
+	clientsMu sync.RWMutex
+)
+
+type clientKeyType struct{}
+
+// RoundTripperMiddleware - function wrapping a client round tripper
+type RoundTripperMiddleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc - adapter for a function as a http.RoundTripper
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// ClientBuilder - builder for a http.Client. Certificates are verified unless InsecureSkipVerify is set, which is
+// refused for a prod build or the production environment.
+type ClientBuilder struct {
+	timeout     time.Duration
+	dial        time.Duration
+	handshake   time.Duration
+	idle        time.Duration
+	maxIdle     int
+	maxIdleHost int
+	maxHost     int
+	insecure    bool
+	serverName  string
+	rootCAs     []string
+	certFile    string
+	keyFile     string
+	proxy       func(*http.Request) (*url.URL, error)
+	middlewares []RoundTripperMiddleware
+	err         error
+}
+
+// NewClientBuilder - create a builder with the default timeout and pool sizes, and the environment proxy settings
+func NewClientBuilder() *ClientBuilder {
+	return &ClientBuilder{
+		timeout:     DefaultClientTimeout,
+		maxIdle:     DefaultMaxIdleConns,
+		maxIdleHost: DefaultMaxIdleConnsPerHost,
+		proxy:       http.ProxyFromEnvironment,
+	}
+}
+
+// Timeout - the overall request timeout, zero is no timeout
+func (b *ClientBuilder) Timeout(d time.Duration) *ClientBuilder {
+	b.timeout = d
+	return b
+}
+
+// DialTimeout - the connection timeout
+func (b *ClientBuilder) DialTimeout(d time.Duration) *ClientBuilder {
+	b.dial = d
+	return b
+}
+
+// TLSHandshakeTimeout - the TLS handshake timeout
+func (b *ClientBuilder) TLSHandshakeTimeout(d time.Duration) *ClientBuilder {
+	b.handshake = d
+	return b
+}
+
+// IdleConnTimeout - the timeout for an idle connection in the pool
+func (b *ClientBuilder) IdleConnTimeout(d time.Duration) *ClientBuilder {
+	b.idle = d
+	return b
+}
+
+// Pool - the connection pool sizes, zero is no limit for maxConnsPerHost
+func (b *ClientBuilder) Pool(maxIdleConns, maxIdleConnsPerHost, maxConnsPerHost int) *ClientBuilder {
+	b.maxIdle = maxIdleConns
+	b.maxIdleHost = maxIdleConnsPerHost
+	b.maxHost = maxConnsPerHost
+	return b
+}
+
+// RootCAs - PEM files with the certificate authorities to verify servers, replacing the system pool
+func (b *ClientBuilder) RootCAs(files ...string) *ClientBuilder {
+	b.rootCAs = append(b.rootCAs, files...)
+	return b
+}
+
+// ClientCert - PEM files with the client certificate and key, for mTLS
+func (b *ClientBuilder) ClientCert(certFile, keyFile string) *ClientBuilder {
+	b.certFile = certFile
+	b.keyFile = keyFile
+	return b
+}
+
+// ServerName - the server name to verify, if it differs from the request host
+func (b *ClientBuilder) ServerName(name string) *ClientBuilder {
+	b.serverName = name
+	return b
+}
+
+// InsecureSkipVerify - do not verify server certificates, for local development only
+func (b *ClientBuilder) InsecureSkipVerify() *ClientBuilder {
+	b.insecure = true
+	return b
+}
+
+// Proxy - the proxy url for all requests, an empty url disables the environment proxy settings
+func (b *ClientBuilder) Proxy(uri string) *ClientBuilder {
+	if uri == "" {
+		b.proxy = nil
+		return b
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		b.setErr(errors.New(fmt.Sprintf("invalid argument: proxy url is invalid [%v]", err)))
+		return b
+	}
+	b.proxy = http.ProxyURL(u)
+	return b
+}
+
+// Use - add round tripper middleware, the first middleware is the outermost
+func (b *ClientBuilder) Use(m ...RoundTripperMiddleware) *ClientBuilder {
+	b.middlewares = append(b.middlewares, m...)
+	return b
+}
+
+func (b *ClientBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Build - create the client
+func (b *ClientBuilder) Build() (*http.Client, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	cfg, err := b.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	t, ok := http.DefaultTransport.(*http.Transport)
+	if ok {
+		// Used clone instead of assignment due to presence of sync.Mutex fields
+		t = t.Clone()
+	} else {
+		t = new(http.Transport)
+	}
+	t.TLSClientConfig = cfg
+	t.Proxy = b.proxy
+	t.MaxIdleConns = b.maxIdle
+	t.MaxIdleConnsPerHost = b.maxIdleHost
+	t.MaxConnsPerHost = b.maxHost
+	if b.dial > 0 {
+		t.DialContext = (&net.Dialer{Timeout: b.dial, KeepAlive: 30 * time.Second}).DialContext
+	}
+	if b.handshake > 0 {
+		t.TLSHandshakeTimeout = b.handshake
+	}
+	if b.idle > 0 {
+		t.IdleConnTimeout = b.idle
+	}
+	var rt http.RoundTripper = t
+	for i := len(b.middlewares) - 1; i >= 0; i-- {
+		if b.middlewares[i] != nil {
+			rt = b.middlewares[i](rt)
+		}
+	}
+	return &http.Client{Transport: rt, Timeout: b.timeout}, nil
+}
+
+func (b *ClientBuilder) tlsConfig() (*tls.Config, error) {
+	cfg := &tls.Config{ServerName: b.serverName, MinVersion: tls.VersionTLS12}
+	if b.insecure {
+		if runtime.IsProdBuild() || runtime.IsProdEnvironment() {
+			return nil, errors.New("invalid argument: skipping certificate verification is not allowed in production")
+		}
+		cfg.InsecureSkipVerify = true
+	}
+	if len(b.rootCAs) > 0 {
+		pool := x509.NewCertPool()
+		for _, name := range b.rootCAs {
+			buf, err := os.ReadFile(name)
+			if err != nil {
+				return nil, err
+			}
+			if !pool.AppendCertsFromPEM(buf) {
+				return nil, errors.New(fmt.Sprintf("invalid argument: root CA file contains no certificates [%v]", name))
+			}
+		}
+		cfg.RootCAs = pool
+	}
+	if b.certFile != "" || b.keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(b.certFile, b.keyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// RegisterClient - register a named client
+func RegisterClient(name string, c *http.Client) error {
+	if name == "" || c == nil {
+		return errors.New(fmt.Sprintf("invalid argument: client name is empty or client is nil [%v]", name))
+	}
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	clients[name] = c
+	return nil
+}
+
+// LookupClient - a named client
+func LookupClient(name string) (*http.Client, bool) {
+	clientsMu.RLock()
+	defer clientsMu.RUnlock()
+	c, ok := clients[name]
+	return c, ok
+}
+
+// SetUpstreamClient - select a named client for all requests to an upstream host, an empty name removes the
+// selection
+func SetUpstreamClient(host, name string) error {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	if name == "" {
+		delete(upstreams, host)
+		return nil
+	}
+	if _, ok := clients[name]; !ok {
+		return errors.New(fmt.Sprintf("invalid argument: client is not registered [%v]", name))
+	}
+	upstreams[host] = name
+	return nil
+}
+
+// NewClientContext - create a new context with a client for Do
+func NewClientContext(ctx context.Context, c *http.Client) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return runtime.ContextWithValue(ctx, clientKey, c)
+}
+
+// ClientFromContext - the client in a context
+func ClientFromContext(ctx context.Context) (*http.Client, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	c, ok := ctx.Value(clientKey).(*http.Client)
+	return c, ok && c != nil
+}
+
+// clientFor - the client for a request: a client in the request context, the client selected for the upstream
+// host, or the package Client
+func clientFor(req *http.Request) *http.Client {
+	if c, ok := ClientFromContext(req.Context()); ok {
+		return c
+	}
+	clientsMu.RLock()
+	defer clientsMu.RUnlock()
+	if name, ok := upstreams[req.URL.Host]; ok {
+		if c, ok1 := clients[name]; ok1 {
+			return c
+		}
+	}
+	return Client
+}
@@ -0,0 +1,63 @@
+package http2
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func writeRootCA(s *httptest.Server) string {
+	name := filepath.Join(os.TempDir(), "http2-client-test-ca.pem")
+	os.WriteFile(name, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw}), 0644)
+	return name
+}
+
+func ExampleClientBuilder() {
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"Id":"agent-1","Name":"%v"}`, r.Header.Get("x-client"))
+	}))
+	defer s.Close()
+	ca := writeRootCA(s)
+	defer os.Remove(ca)
+
+	_, status := Get[agent](nil, s.URL, nil)
+	fmt.Printf("test: Get(default) -> [status:%v] [unknown-authority:%v]\n", status.Code(), strings.Contains(status.FirstError().Error(), "certificate"))
+
+	c, err := NewClientBuilder().RootCAs(ca).Use(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("x-client", "builder")
+			return next.RoundTrip(req)
+		})
+	}).Build()
+	a, status := Get[agent](nil, s.URL, &Options{Client: c})
+	fmt.Printf("test: Get(options) -> [err:%v] [agent:%v] [status:%v]\n", err, a, status)
+
+	RegisterClient("internal", c)
+	u, _ := url.Parse(s.URL)
+	err = SetUpstreamClient(u.Host, "internal")
+	defer SetUpstreamClient(u.Host, "")
+	a, status = Get[agent](nil, s.URL, nil)
+	fmt.Printf("test: Get(upstream) -> [err:%v] [agent:%v] [status:%v]\n", err, a, status)
+
+	fmt.Printf("test: SetUpstreamClient() -> [err:%v]\n", SetUpstreamClient(u.Host, "invalid"))
+
+	_, err = NewClientBuilder().RootCAs("invalid.pem").Build()
+	fmt.Printf("test: Build() -> [err:%v]\n", err != nil)
+
+	_, err = NewClientBuilder().Proxy("http://proxy:port").Build()
+	fmt.Printf("test: Build() -> [err:%v]\n", err)
+
+	//Output:
+	//test: Get(default) -> [status:500] [unknown-authority:true]
+	//test: Get(options) -> [err:<nil>] [agent:{agent-1 builder}] [status:OK]
+	//test: Get(upstream) -> [err:<nil>] [agent:{agent-1 builder}] [status:OK]
+	//test: SetUpstreamClient() -> [err:invalid argument: client is not registered [invalid]]
+	//test: Build() -> [err:true]
+	//test: Build() -> [err:invalid argument: proxy url is invalid [parse "http://proxy:port": invalid port ":port" after host]]
+
+}
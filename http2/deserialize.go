@@ -10,7 +10,8 @@ import (
 
 var deserializeLoc = PkgUri + "/Deserialize"
 
-// Deserialize - provide deserialization of a request/response body
+// Deserialize - provide deserialization of a request/response body, a response body from Do is already
+// decompressed
 func Deserialize[T any](body io.ReadCloser) (T, *runtime.Status) {
 	var t T
 
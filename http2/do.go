@@ -1,11 +1,9 @@
 package http2
 
 import (
-	"crypto/tls"
 	"errors"
 	"github.com/go-ai-agent/core/runtime"
 	"net/http"
-	"time"
 )
 
 // Exchange - interface for Http request/response interaction
@@ -13,23 +11,21 @@ type Exchange func(req *http.Request) (*http.Response, error)
 
 var (
 	doLocation = PkgUri + "/Do"
-	Client     = http.DefaultClient
+	Client     = defaultClient()
 )
 
-func init() {
-	t, ok := http.DefaultTransport.(*http.Transport)
-	if ok {
-		// Used clone instead of assignment due to presence of sync.Mutex fields
-		var transport = t.Clone()
-		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-		transport.MaxIdleConns = 200
-		transport.MaxIdleConnsPerHost = 100
-		Client = &http.Client{Transport: transport, Timeout: time.Second * 5}
-	} else {
-		Client = &http.Client{Transport: http.DefaultTransport, Timeout: time.Second * 5}
+// defaultClient - the default client verifies certificates, use a ClientBuilder for other settings
+func defaultClient() *http.Client {
+	c, err := NewClientBuilder().Build()
+	if err != nil {
+		return &http.Client{Transport: http.DefaultTransport, Timeout: DefaultClientTimeout}
 	}
+	return c
 }
 
+// Do - send a request with the client in the request context, the client selected for the upstream host,
+// or the package Client. A response body with a Content-Encoding is decompressed, and an unsupported encoding is
+// an error status, with the body unread.
 func Do(req *http.Request) (resp *http.Response, status *runtime.Status) {
 	if req == nil {
 		return nil, runtime.NewStatusError(runtime.StatusInvalidArgument, doLocation, errors.New("invalid argument : request is nil")) //.SetCode(runtime.StatusInvalidArgument)
@@ -55,7 +51,7 @@ func Do(req *http.Request) (resp *http.Response, status *runtime.Status) {
 	if doProxy != nil {
 		resp, err = doProxy(req)
 	} else {
-		resp, err = Client.Do(req)
+		resp, err = clientFor(req).Do(req)
 	}
 	if err != nil {
 		// can happen because of an errant proxy, or when there is a connectivity error, even with a valid URL
@@ -64,6 +60,9 @@ func Do(req *http.Request) (resp *http.Response, status *runtime.Status) {
 		}
 		return resp, runtime.NewStatusError(resp.StatusCode, doLocation, err)
 	}
+	if status = decompressResponse(req, resp); !status.OK() {
+		return resp, status.AddLocation(doLocation)
+	}
 	return resp, runtime.NewStatus(resp.StatusCode)
 }
 
@@ -0,0 +1,359 @@
+package http2
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"github.com/klauspost/compress/zstd"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	AcceptEncoding  = "Accept-Encoding"
+	ContentEncoding = "Content-Encoding"
+	Vary            = "Vary"
+	GzipEncoding    = "gzip"
+	ZstdEncoding    = "zstd"
+	identity        = "identity"
+)
+
+var (
+	negotiateLoc  = PkgUri + "/WriteNegotiated"
+	decompressLoc = PkgUri + "/Decompress"
+
+	// CompressMinSize - the minimum body size in bytes for compression
+	CompressMinSize = 1024
+
+	encoders     = []Encoder{jsonEncoder{}, textEncoder{}}
+	compressions = []Compression{{
+		Encoding:  GzipEncoding,
+		NewWriter: func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+		NewReader: func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) },
+	}, {
+		Encoding:  ZstdEncoding,
+		NewWriter: newZstdWriter,
+		NewReader: newZstdReader,
+	}}
+	codecMu sync.RWMutex
+)
+
+// Encoder - content encoder for a media type, selected from the request Accept header
+type Encoder interface {
+	ContentType() string
+	Encode(content any) ([]byte, error)
+}
+
+// Compression - content coding selected from the request Accept-Encoding header, and used to decompress a body
+// with the Content-Encoding. Gzip and zstd are built in, and others can be added with RegisterCompression.
+type Compression struct {
+	Encoding  string
+	NewWriter func(w io.Writer) io.WriteCloser
+	NewReader func(r io.Reader) (io.ReadCloser, error)
+}
+
+func newZstdWriter(w io.Writer) io.WriteCloser {
+	zw, err := zstd.NewWriter(w)
+	if err != nil {
+		// only returned for invalid options
+		panic(err)
+	}
+	return zw
+}
+
+func newZstdReader(r io.Reader) (io.ReadCloser, error) {
+	zr, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return zr.IOReadCloser(), nil
+}
+
+type jsonEncoder struct{}
+
+func (jsonEncoder) ContentType() string { return ContentTypeJson }
+func (jsonEncoder) Encode(content any) ([]byte, error) {
+	return json.Marshal(content)
+}
+
+type textEncoder struct{}
+
+func (textEncoder) ContentType() string { return ContentTypeText }
+func (textEncoder) Encode(content any) ([]byte, error) {
+	return []byte(fmt.Sprintf("%v", content)), nil
+}
+
+// RegisterEncoder - add an encoder, or replace the encoder for a media type
+func RegisterEncoder(e Encoder) {
+	if e == nil {
+		return
+	}
+	codecMu.Lock()
+	defer codecMu.Unlock()
+	for i, e2 := range encoders {
+		if e2.ContentType() == e.ContentType() {
+			encoders[i] = e
+			return
+		}
+	}
+	encoders = append(encoders, e)
+}
+
+// RegisterCompression - add a compression, or replace the compression for an encoding
+func RegisterCompression(c Compression) error {
+	if c.Encoding == "" || c.NewWriter == nil || c.NewReader == nil {
+		return errors.New(fmt.Sprintf("invalid argument: compression is incomplete [%v]", c.Encoding))
+	}
+	codecMu.Lock()
+	defer codecMu.Unlock()
+	for i, c2 := range compressions {
+		if c2.Encoding == c.Encoding {
+			compressions[i] = c
+			return nil
+		}
+	}
+	compressions = append(compressions, c)
+	return nil
+}
+
+func getEncoders() []Encoder {
+	codecMu.RLock()
+	defer codecMu.RUnlock()
+	return encoders
+}
+
+func getCompressions() []Compression {
+	codecMu.RLock()
+	defer codecMu.RUnlock()
+	return compressions
+}
+
+// Negotiate - select the offer with the highest quality in an Accept or Accept-Encoding header, the most specific
+// range determines the quality of an offer, and offers are preferred in order. An empty header accepts the first
+// offer.
+func Negotiate(header string, offers []string) (string, bool) {
+	if len(offers) == 0 {
+		return "", false
+	}
+	if strings.TrimSpace(header) == "" {
+		return offers[0], true
+	}
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		if q := quality(header, offer); q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best, bestQ > 0
+}
+
+func quality(header, offer string) float64 {
+	offer = strings.ToLower(strings.TrimSpace(strings.Split(offer, ";")[0]))
+	q, specificity := 0.0, 0
+	for _, s := range strings.Split(header, ",") {
+		t := strings.Split(s, ";")
+		r := strings.ToLower(strings.TrimSpace(t[0]))
+		n := 0
+		switch {
+		case r == offer:
+			n = 3
+		case strings.HasSuffix(r, "/*") && strings.HasPrefix(offer, r[:len(r)-1]):
+			n = 2
+		case r == "*/*" || r == "*":
+			n = 1
+		}
+		if n <= specificity {
+			continue
+		}
+		specificity = n
+		q = 1
+		for _, p := range t[1:] {
+			if k, v, ok := strings.Cut(strings.TrimSpace(p), "="); ok && strings.TrimSpace(k) == "q" {
+				if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+					q = f
+				}
+			}
+		}
+	}
+	return q
+}
+
+// WriteNegotiated - templated function to write a http.Response like WriteResponse, with the content type
+// negotiated from the request Accept header, and the body compressed as negotiated from the Accept-Encoding
+// header. Content that is not []byte, string, or an io.Reader, and has no content type in the headers, is
+// encoded by the first registered encoder accepted, and a not acceptable problem is written if there is none.
+// Status content takes precedence over the content, as for WriteResponse, and a nil request writes with
+// WriteResponse.
+func WriteNegotiated[E runtime.ErrorHandler](w http.ResponseWriter, r *http.Request, content any, status *runtime.Status, headers any) {
+	var e E
+	var buf []byte
+	var ct string
+
+	if status == nil {
+		status = runtime.NewStatusOK()
+	}
+	if r == nil {
+		WriteResponse[E](w, content, status, headers)
+		return
+	}
+	contentType := GetContentType(headers)
+	if status.Content() != nil {
+		content = status.Content()
+		contentType = status.Header().Get(ContentType)
+	}
+	if content == nil {
+		WriteResponse[E](w, nil, status, headers)
+		return
+	}
+	switch content.(type) {
+	case []byte, string, error, io.Reader:
+		var status0 *runtime.Status
+
+		buf, ct, status0 = WriteBytes(content, contentType)
+		if !status0.OK() {
+			e.Handle(status0, status.RequestId(), negotiateLoc)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	default:
+		var enc Encoder
+
+		for _, e2 := range getEncoders() {
+			if contentType != "" && e2.ContentType() == contentType {
+				enc = e2
+				break
+			}
+		}
+		if enc == nil {
+			enc = negotiateEncoder(r.Header.Get(Accept))
+			w.Header().Add(Vary, Accept)
+		}
+		if enc == nil {
+			writeProblem[E](w, NewProblem(runtime.NewStatus(http.StatusNotAcceptable).SetRequestId(r), r))
+			return
+		}
+		var err error
+		if buf, err = enc.Encode(content); err != nil {
+			e.Handle(runtime.NewStatusError(runtime.StatusJsonEncodeError, negotiateLoc, err), status.RequestId(), negotiateLoc)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		ct = enc.ContentType()
+	}
+	SetHeaders(w, headers)
+	w.Header().Add(Vary, AcceptEncoding)
+	if c, ok := negotiateCompression(r.Header.Get(AcceptEncoding), len(buf)); ok {
+		if cbuf, err := compress(c, buf); err == nil {
+			buf = cbuf
+			w.Header().Set(ContentEncoding, c.Encoding)
+		} else {
+			e.Handle(runtime.NewStatusError(http.StatusInternalServerError, negotiateLoc, err), status.RequestId(), negotiateLoc)
+		}
+	}
+	w.Header().Set(ContentType, ct)
+	w.Header().Set(ContentLength, fmt.Sprintf("%v", len(buf)))
+	w.WriteHeader(status.Http())
+	if _, err := w.Write(buf); err != nil {
+		e.Handle(runtime.NewStatusError(http.StatusInternalServerError, negotiateLoc, err), status.RequestId(), negotiateLoc)
+	}
+}
+
+func negotiateEncoder(accept string) Encoder {
+	var offers []string
+	m := make(map[string]Encoder)
+	for _, e := range getEncoders() {
+		offers = append(offers, e.ContentType())
+		m[e.ContentType()] = e
+	}
+	ct, ok := Negotiate(accept, offers)
+	if !ok {
+		return nil
+	}
+	return m[ct]
+}
+
+func negotiateCompression(acceptEncoding string, size int) (Compression, bool) {
+	if strings.TrimSpace(acceptEncoding) == "" || size < CompressMinSize {
+		return Compression{}, false
+	}
+	var offers []string
+	m := make(map[string]Compression)
+	for _, c := range getCompressions() {
+		offers = append(offers, c.Encoding)
+		m[c.Encoding] = c
+	}
+	encoding, ok := Negotiate(acceptEncoding, offers)
+	return m[encoding], ok
+}
+
+func compress(c Compression, buf []byte) ([]byte, error) {
+	var b bytes.Buffer
+	w := c.NewWriter(&b)
+	if _, err := w.Write(buf); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// Decompress - a reader that decompresses a body with the Content-Encoding of a registered compression, a body
+// without a Content-Encoding, or with the identity encoding, is returned as is. Content that is itself compressed,
+// such as an application/gzip download without a Content-Encoding, is not decompressed. On failure, the body is
+// not closed.
+func Decompress(h http.Header, body io.ReadCloser) (io.ReadCloser, *runtime.Status) {
+	encoding := ""
+	if h != nil {
+		encoding = strings.ToLower(strings.TrimSpace(h.Get(ContentEncoding)))
+	}
+	if body == nil || encoding == "" || encoding == identity {
+		return body, runtime.NewStatusOK()
+	}
+	for _, c := range getCompressions() {
+		if c.Encoding != encoding {
+			continue
+		}
+		cr, err := c.NewReader(body)
+		if err != nil {
+			return nil, runtime.NewStatusError(runtime.StatusIOError, decompressLoc, err)
+		}
+		return readCloser{Reader: cr, close: func() error { cr.Close(); return body.Close() }}, runtime.NewStatusOK()
+	}
+	return nil, runtime.NewStatusError(runtime.StatusInvalidContent, decompressLoc, errors.New(fmt.Sprintf("invalid content: content encoding is not supported [%v]", encoding)))
+}
+
+// decompressResponse - replace the body of a response with a Content-Encoding with the decompressed body, and
+// remove the Content-Encoding and Content-Length headers, as the http.Transport does for gzip. A response without
+// a body is not changed.
+func decompressResponse(req *http.Request, resp *http.Response) *runtime.Status {
+	if resp == nil || resp.Body == nil || resp.Header.Get(ContentEncoding) == "" {
+		return runtime.NewStatusOK()
+	}
+	if (req != nil && req.Method == http.MethodHead) || resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotModified || resp.ContentLength == 0 {
+		return runtime.NewStatusOK()
+	}
+	body, status := Decompress(resp.Header, resp.Body)
+	if !status.OK() {
+		return status
+	}
+	resp.Body = body
+	resp.Header.Del(ContentEncoding)
+	resp.Header.Del(ContentLength)
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return status
+}
+
+type readCloser struct {
+	io.Reader
+	close func() error
+}
+
+func (r readCloser) Close() error { return r.close() }
@@ -0,0 +1,140 @@
+package http2
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+func ExampleNegotiate() {
+	offers := []string{ContentTypeJson, ContentTypeText}
+	for _, accept := range []string{"", "text/*", "text/plain;q=0.9, application/json;q=0.5", "application/*;q=0.2, */*;q=0.8", "image/png"} {
+		ct, ok := Negotiate(accept, offers)
+		fmt.Printf("test: Negotiate(%v) -> [%v] [ok:%v]\n", accept, ct, ok)
+	}
+
+	//Output:
+	//test: Negotiate() -> [application/json] [ok:true]
+	//test: Negotiate(text/*) -> [text/plain] [ok:true]
+	//test: Negotiate(text/plain;q=0.9, application/json;q=0.5) -> [text/plain] [ok:true]
+	//test: Negotiate(application/*;q=0.2, */*;q=0.8) -> [text/plain] [ok:true]
+	//test: Negotiate(image/png) -> [] [ok:false]
+
+}
+
+func ExampleWriteNegotiated() {
+	content := searchResp{Query: strings.Repeat("golang ", 200), Count: 2}
+
+	for _, h := range []http.Header{
+		{Accept: []string{"text/plain"}},
+		{Accept: []string{"image/png"}},
+		{Accept: []string{ContentTypeJson}, AcceptEncoding: []string{"br, gzip;q=0.8"}},
+		{Accept: []string{ContentTypeJson}, AcceptEncoding: []string{"zstd"}},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/search", nil)
+		req.Header = h
+		w := httptest.NewRecorder()
+		WriteNegotiated[runtime.BypassError](w, req, content, nil, nil)
+		resp := w.Result()
+		fmt.Printf("test: WriteNegotiated(%v) -> [code:%v] [content-type:%v] [encoding:%v] [vary:%v]\n", h, resp.StatusCode, resp.Header.Get(ContentType), resp.Header.Get(ContentEncoding), resp.Header.Values(Vary))
+
+		if resp.Header.Get(ContentEncoding) != "" {
+			body, _ := Decompress(resp.Header, resp.Body)
+			t, status := Deserialize[searchResp](body)
+			fmt.Printf("test: Deserialize() -> [count:%v] [len:%v] [status:%v]\n", t.Count, len(t.Query), status)
+		}
+	}
+
+	//Output:
+	//test: WriteNegotiated(map[Accept:[text/plain]]) -> [code:200] [content-type:text/plain] [encoding:] [vary:[Accept Accept-Encoding]]
+	//test: WriteNegotiated(map[Accept:[image/png]]) -> [code:406] [content-type:application/problem+json] [encoding:] [vary:[Accept]]
+	//test: WriteNegotiated(map[Accept:[application/json] Accept-Encoding:[br, gzip;q=0.8]]) -> [code:200] [content-type:application/json] [encoding:gzip] [vary:[Accept Accept-Encoding]]
+	//test: Deserialize() -> [count:2] [len:1400] [status:OK]
+	//test: WriteNegotiated(map[Accept:[application/json] Accept-Encoding:[zstd]]) -> [code:200] [content-type:application/json] [encoding:zstd] [vary:[Accept Accept-Encoding]]
+	//test: Deserialize() -> [count:2] [len:1400] [status:OK]
+
+}
+
+func ExampleWriteNegotiated_status() {
+	h := NewHandler[runtime.BypassError](func(ctx context.Context, w http.ResponseWriter, r *http.Request) *runtime.Status {
+		return runtime.NewStatusOK().SetContent(searchResp{Query: strings.Repeat("golang ", 200), Count: 2}, true)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/search", nil)
+	req.Header.Set(AcceptEncoding, "gzip")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	resp := w.Result()
+	body, _ := Decompress(resp.Header, resp.Body)
+	t, status := Deserialize[searchResp](body)
+	fmt.Printf("test: NewHandler() -> [code:%v] [content-type:%v] [encoding:%v] [count:%v] [status:%v]\n", resp.StatusCode, resp.Header.Get(ContentType), resp.Header.Get(ContentEncoding), t.Count, status)
+
+	//Output:
+	//test: NewHandler() -> [code:200] [content-type:application/json] [encoding:gzip] [count:2] [status:OK]
+
+}
+
+func ExampleDecompress() {
+	var b bytes.Buffer
+	zw := gzip.NewWriter(&b)
+	zw.Write([]byte(`{"Query":"golang","Count":2}`))
+	zw.Close()
+	artifact := b.Bytes()
+
+	b = bytes.Buffer{}
+	zw2 := newZstdWriter(&b)
+	zw2.Write([]byte(`{"Query":"zstd","Count":3}`))
+	zw2.Close()
+	zstdBody := b.Bytes()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/artifact.gz":
+			w.Header().Set(ContentType, "application/gzip")
+		case "/search":
+			w.Header().Set(ContentType, ContentTypeJson)
+			w.Header().Set(ContentEncoding, GzipEncoding)
+		case "/zstd":
+			w.Header().Set(ContentType, ContentTypeJson)
+			w.Header().Set(ContentEncoding, ZstdEncoding)
+			w.Write(zstdBody)
+			return
+		case "/brotli":
+			w.Header().Set(ContentEncoding, "br")
+		}
+		w.Write(artifact)
+	}))
+	defer s.Close()
+	// Set Accept-Encoding, so that the transport does not decompress
+	opts := &Options{Header: http.Header{AcceptEncoding: []string{GzipEncoding}}}
+
+	buf, status := Get[[]byte](nil, s.URL+"/artifact.gz", opts)
+	fmt.Printf("test: Get(artifact) -> [status:%v] [unchanged:%v]\n", status, bytes.Equal(buf, artifact))
+
+	t, status := Get[searchResp](nil, s.URL+"/search", opts)
+	fmt.Printf("test: Get(search) -> [status:%v] [resp:%v]\n", status, t)
+
+	t, status = Get[searchResp](nil, s.URL+"/zstd", opts)
+	fmt.Printf("test: Get(zstd) -> [status:%v] [resp:%v]\n", status, t)
+
+	req, _ := http.NewRequest(http.MethodGet, s.URL+"/search", nil)
+	req.Header.Set(AcceptEncoding, GzipEncoding)
+	resp, status := Do(req)
+	t, status = Deserialize[searchResp](resp.Body)
+	fmt.Printf("test: Do(search) -> [status:%v] [resp:%v] [encoding:%v] [length:%v]\n", status, t, resp.Header.Get(ContentEncoding), resp.ContentLength)
+
+	_, status = Get[searchResp](nil, s.URL+"/brotli", opts)
+	fmt.Printf("test: Get(brotli) -> [status:%v]\n", status)
+
+	//Output:
+	//test: Get(artifact) -> [status:OK] [unchanged:true]
+	//test: Get(search) -> [status:OK] [resp:{golang 2}]
+	//test: Get(zstd) -> [status:OK] [resp:{zstd 3}]
+	//test: Do(search) -> [status:OK] [resp:{golang 2}] [encoding:] [length:-1]
+	//test: Get(brotli) -> [status:Invalid Content [invalid content: content encoding is not supported [br]]]
+
+}
@@ -7,6 +7,12 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
+)
+
+var (
+	originHosts = make(map[string]bool)
+	originMu    sync.RWMutex
 )
 
 type nopCloser struct {
@@ -98,9 +104,38 @@ func NewRequest(ctx any, method string, uri any, variant string, body io.Reader)
 		req.Header.Add(ContentLocation, variant)
 	}
 	req.Header.Add(runtime.XRequestId, requestId)
+	if isOriginHost(req.URL.Host) {
+		runtime.AddOriginHeaders(req.Header)
+	}
 	return req, runtime.NewStatusOK()
 }
 
+// SetOriginHosts - set the internal upstream hosts that NewRequest sends the origin headers to, replacing any
+// previous hosts. Upstream hosts with a named client are also sent the origin headers, other hosts are not.
+func SetOriginHosts(hosts ...string) {
+	originMu.Lock()
+	defer originMu.Unlock()
+	originHosts = make(map[string]bool)
+	for _, h := range hosts {
+		if h != "" {
+			originHosts[h] = true
+		}
+	}
+}
+
+func isOriginHost(host string) bool {
+	originMu.RLock()
+	ok := originHosts[host]
+	originMu.RUnlock()
+	if ok {
+		return true
+	}
+	clientsMu.RLock()
+	defer clientsMu.RUnlock()
+	_, ok = upstreams[host]
+	return ok
+}
+
 func newContext(ctx any) context.Context {
 	if ctx == nil {
 		return context.Background()
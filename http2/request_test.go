@@ -74,3 +74,28 @@ func Example_NewRequest() {
 
 
 */
+
+func ExampleNewRequest_origin() {
+	runtime.SetOrigin("us-west", "oregon", "dc1", "search", "")
+	defer runtime.SetOrigin("region", "zone", "", "", "")
+
+	req, status := NewRequest(nil, "", "https://www.google.com/search?q=golang", "", nil)
+	fmt.Printf("test: NewRequest() -> [status:%v] [empty:%v]\n", status, runtime.OriginFromHeader(req.Header).IsEmpty())
+
+	SetOriginHosts("search.internal:8080")
+	defer SetOriginHosts()
+	req, status = NewRequest(nil, "", "http://search.internal:8080/search?q=golang", "", nil)
+	fmt.Printf("test: NewRequest(internal) -> [status:%v] [origin:%v]\n", status, runtime.OriginFromHeader(req.Header))
+
+	RegisterClient("upstream-test", Client)
+	SetUpstreamClient("named.internal", "upstream-test")
+	defer SetUpstreamClient("named.internal", "")
+	req, status = NewRequest(nil, "", "http://named.internal/search?q=golang", "", nil)
+	fmt.Printf("test: NewRequest(named) -> [status:%v] [origin:%v]\n", status, runtime.OriginFromHeader(req.Header))
+
+	//Output:
+	//test: NewRequest() -> [status:OK] [empty:true]
+	//test: NewRequest(internal) -> [status:OK] [origin:{us-west oregon dc1 search }]
+	//test: NewRequest(named) -> [status:OK] [origin:{us-west oregon dc1 search }]
+
+}
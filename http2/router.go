@@ -0,0 +1,276 @@
+package http2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	strings2 "github.com/go-ai-agent/core/strings"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	Allow      = "Allow"
+	AnyMethod  = "*"
+	paramToken = "\x00"
+)
+
+var (
+	routerLoc       = PkgUri + "/Router/Handle"
+	pathParamsKey   = &pathParamsKeyType{}
+	errInvalidRoute = "invalid argument: route %v [%v]"
+)
+
+type pathParamsKeyType struct{}
+
+// RouteInfo - a registered route, for documentation
+type RouteInfo struct {
+	Method  string
+	Pattern string
+}
+
+type route struct {
+	info     RouteInfo
+	segments []string
+	names    []string
+	handler  runtime.HttpHandler
+}
+
+// Router - method and path pattern router for a runtime.HttpHandler. Patterns use the strings.Expand {name}
+// syntax for path parameters, such as /agents/{id}/tasks/{task}. When several routes match a path, the first
+// segment that is static in one route and a parameter in another decides, static first, then an exact method
+// takes precedence over a GET route for a HEAD request, which takes precedence over AnyMethod. Path parameters
+// are available from the handler context via PathParams and PathParam.
+type Router struct {
+	routes []*route
+	mu     sync.RWMutex
+}
+
+// NewRouter - create a router
+func NewRouter() *Router {
+	return new(Router)
+}
+
+// Add - add a route, a method of AnyMethod matches all methods
+func (rt *Router) Add(method, pattern string, h runtime.HttpHandler) error {
+	if h == nil {
+		return errors.New(fmt.Sprintf(errInvalidRoute, "handler is nil", pattern))
+	}
+	if method == "" {
+		return errors.New(fmt.Sprintf(errInvalidRoute, "method is empty", pattern))
+	}
+	r, err := newRoute(strings.ToUpper(method), pattern)
+	if err != nil {
+		return err
+	}
+	r.handler = h
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	for _, r2 := range rt.routes {
+		// Parameter names are ignored, so /agents/{id} and /agents/{name} are duplicates, including for AnyMethod
+		if r2.info.Method == r.info.Method && strings.Join(r2.segments, "/") == strings.Join(r.segments, "/") {
+			return errors.New(fmt.Sprintf(errInvalidRoute, "is a duplicate of "+r2.info.Pattern, pattern))
+		}
+	}
+	rt.routes = append(rt.routes, r)
+	return nil
+}
+
+// AddTyped - templated function to add a route for a TypedHandler
+func AddTyped[E runtime.ErrorHandler, Req, Resp any](rt *Router, method, pattern string, h TypedHandler[Req, Resp]) error {
+	if h == nil {
+		return errors.New(fmt.Sprintf(errInvalidRoute, "handler is nil", pattern))
+	}
+	return rt.Add(method, pattern, TypedHttp[E](h))
+}
+
+// Routes - the registered routes, in registration order
+func (rt *Router) Routes() []RouteInfo {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+	var routes []RouteInfo
+	for _, r := range rt.routes {
+		routes = append(routes, r.info)
+	}
+	return routes
+}
+
+// Handle - a runtime.HttpHandler dispatching to the matching route, a HEAD request is dispatched to a GET route
+// if there is no HEAD route. A path with no route returns a not found status, and a path with no route for the
+// method returns a method not allowed status, with an Allow header.
+func (rt *Router) Handle(ctx context.Context, w http.ResponseWriter, r *http.Request) *runtime.Status {
+	segments := splitPath(r.URL.EscapedPath())
+	var match *route
+	var params map[string]string
+	var allow []string
+	var rank int
+
+	rt.mu.RLock()
+	for _, rte := range rt.routes {
+		p, ok := rte.match(segments)
+		if !ok {
+			continue
+		}
+		mr := rte.methodRank(r.Method)
+		if mr == 0 {
+			allow = append(allow, rte.info.Method)
+			if rte.info.Method == http.MethodGet {
+				allow = append(allow, http.MethodHead)
+			}
+			continue
+		}
+		c := 1
+		if match != nil {
+			c = rte.compare(match)
+		}
+		if c > 0 || (c == 0 && mr > rank) {
+			match, params, rank = rte, p, mr
+		}
+	}
+	rt.mu.RUnlock()
+	if match == nil {
+		if len(allow) == 0 {
+			return runtime.NewStatus(http.StatusNotFound).SetRequestId(ctx)
+		}
+		status := runtime.NewStatus(http.StatusMethodNotAllowed).SetRequestId(ctx)
+		status.Header().Set(Allow, allowHeader(allow))
+		return status
+	}
+	if ctx == nil {
+		ctx = r.Context()
+	}
+	return match.handler(runtime.ContextWithValue(ctx, pathParamsKey, params), w, r)
+}
+
+// PathParams - the path parameters for a request, from the handler context
+func PathParams(ctx any) map[string]string {
+	if c, ok := ctx.(context.Context); ok && c != nil {
+		if m, ok2 := c.Value(pathParamsKey).(map[string]string); ok2 {
+			return m
+		}
+	}
+	return nil
+}
+
+// PathParam - a path parameter for a request, from the handler context
+func PathParam(ctx any, name string) string {
+	return PathParams(ctx)[name]
+}
+
+// ExpandPath - build a path from a pattern and path parameters, the parameters are escaped
+func ExpandPath(pattern string, params map[string]string) (string, error) {
+	return strings2.Expand(func(name string) (string, error) {
+		v, ok := params[name]
+		if !ok {
+			return "", errors.New(fmt.Sprintf("invalid argument: path parameter is missing [%v]", name))
+		}
+		return url.PathEscape(v), nil
+	}, pattern)
+}
+
+// newRoute - parse a pattern with strings.Expand, replacing parameters with a token to match any segment
+func newRoute(method, pattern string) (*route, error) {
+	if !strings.HasPrefix(pattern, "/") {
+		return nil, errors.New(fmt.Sprintf(errInvalidRoute, "pattern does not start with /", pattern))
+	}
+	r := &route{info: RouteInfo{Method: method, Pattern: pattern}}
+	path, err := strings2.Expand(func(name string) (string, error) {
+		if name == "" {
+			return "", errors.New(fmt.Sprintf(errInvalidRoute, "parameter name is empty", pattern))
+		}
+		r.names = append(r.names, name)
+		return paramToken, nil
+	}, pattern)
+	if err != nil {
+		return nil, err
+	}
+	r.segments = splitPath(path)
+	for _, s := range r.segments {
+		if s != paramToken && strings.Contains(s, paramToken) {
+			return nil, errors.New(fmt.Sprintf(errInvalidRoute, "parameter is not a full path segment", pattern))
+		}
+	}
+	return r, nil
+}
+
+// methodRank - the precedence of the route for a request method, 0 if the route does not match the method
+func (r *route) methodRank(method string) int {
+	switch {
+	case r.info.Method == method:
+		return 3
+	case r.info.Method == http.MethodGet && method == http.MethodHead:
+		return 2
+	case r.info.Method == AnyMethod:
+		return 1
+	}
+	return 0
+}
+
+// compare - compare the precedence of routes with the same number of segments, the first segment that is static
+// in one route and a parameter in the other decides, and 0 is returned if there is no such segment
+func (r *route) compare(r2 *route) int {
+	for i := range r.segments {
+		if i >= len(r2.segments) {
+			break
+		}
+		p, p2 := r.segments[i] == paramToken, r2.segments[i] == paramToken
+		if p != p2 {
+			if p2 {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
+
+func (r *route) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(r.segments) {
+		return nil, false
+	}
+	var params map[string]string
+	i := 0
+	for j, s := range r.segments {
+		if s != paramToken {
+			if s != segments[j] {
+				return nil, false
+			}
+			continue
+		}
+		v, err := url.PathUnescape(segments[j])
+		if err != nil || v == "" {
+			return nil, false
+		}
+		if params == nil {
+			params = make(map[string]string, len(r.names))
+		}
+		params[r.names[i]] = v
+		i++
+	}
+	return params, true
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+func allowHeader(methods []string) string {
+	m := make(map[string]bool)
+	var t []string
+	for _, s := range methods {
+		if !m[s] {
+			m[s] = true
+			t = append(t, s)
+		}
+	}
+	sort.Strings(t)
+	return strings.Join(t, ", ")
+}
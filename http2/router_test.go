@@ -0,0 +1,78 @@
+package http2
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"net/http"
+	"net/http/httptest"
+)
+
+func routeHandler(name string) runtime.HttpHandler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) *runtime.Status {
+		fmt.Fprintf(w, "%v:%v", name, PathParams(ctx))
+		return runtime.NewStatusOK()
+	}
+}
+
+func ExampleRouter() {
+	rt := NewRouter()
+	rt.Add(http.MethodGet, "/agents/{id}/tasks/{task}", routeHandler("task"))
+	rt.Add(http.MethodGet, "/agents/{id}/tasks/latest", routeHandler("latest"))
+	rt.Add(http.MethodDelete, "/agents/{id}", routeHandler("delete"))
+	rt.Add(http.MethodPut, "/agents/{id}", routeHandler("put"))
+	rt.Add(AnyMethod, "/agents/{id}/status", routeHandler("any"))
+	rt.Add(http.MethodGet, "/agents/{id}/{item}", routeHandler("item"))
+	rt.Add(http.MethodGet, "/agents/latest/{item}", routeHandler("latest-item"))
+	err := rt.Add(http.MethodGet, "/agents/{agent}/tasks/{name}", routeHandler("duplicate"))
+	fmt.Printf("test: Add() -> [err:%v]\n", err)
+	err = rt.Add(AnyMethod, "/agents/{agent}/status", routeHandler("duplicate"))
+	fmt.Printf("test: Add() -> [err:%v]\n", err)
+	fmt.Printf("test: Routes() -> %v\n", rt.Routes())
+
+	h := NewHandler[runtime.BypassError](rt.Handle)
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/agents/agent%201/tasks/100", nil),
+		httptest.NewRequest(http.MethodGet, "/agents/agent-1/tasks/latest", nil),
+		httptest.NewRequest(http.MethodHead, "/agents/agent-1/tasks/latest", nil),
+		httptest.NewRequest(http.MethodPost, "/agents/agent-1/tasks/latest", nil),
+		httptest.NewRequest(http.MethodGet, "/agents/latest/tasks", nil),
+		httptest.NewRequest(http.MethodGet, "/agents/agent-1/status", nil),
+		httptest.NewRequest(http.MethodPost, "/agents/agent-1/status", nil),
+		httptest.NewRequest(http.MethodGet, "/agents/agent-1", nil),
+		httptest.NewRequest(http.MethodGet, "/agents", nil),
+	} {
+		req.Header.Set(runtime.XRequestId, "123")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		fmt.Printf("test: Handle(%v) -> [code:%v] [allow:%v] [body:%v]\n", req.URL.Path, w.Code, w.Result().Header.Get(Allow), w.Body.String())
+	}
+
+	//Output:
+	//test: Add() -> [err:invalid argument: route is a duplicate of /agents/{id}/tasks/{task} [/agents/{agent}/tasks/{name}]]
+	//test: Add() -> [err:invalid argument: route is a duplicate of /agents/{id}/status [/agents/{agent}/status]]
+	//test: Routes() -> [{GET /agents/{id}/tasks/{task}} {GET /agents/{id}/tasks/latest} {DELETE /agents/{id}} {PUT /agents/{id}} {* /agents/{id}/status} {GET /agents/{id}/{item}} {GET /agents/latest/{item}}]
+	//test: Handle(/agents/agent 1/tasks/100) -> [code:200] [allow:] [body:task:map[id:agent 1 task:100]]
+	//test: Handle(/agents/agent-1/tasks/latest) -> [code:200] [allow:] [body:latest:map[id:agent-1]]
+	//test: Handle(/agents/agent-1/tasks/latest) -> [code:200] [allow:] [body:latest:map[id:agent-1]]
+	//test: Handle(/agents/agent-1/tasks/latest) -> [code:405] [allow:GET, HEAD] [body:{"type":"about:blank","title":"Method Not Allowed","status":405,"instance":"/agents/agent-1/tasks/latest","request-id":"123"}]
+	//test: Handle(/agents/latest/tasks) -> [code:200] [allow:] [body:latest-item:map[item:tasks]]
+	//test: Handle(/agents/agent-1/status) -> [code:200] [allow:] [body:any:map[id:agent-1]]
+	//test: Handle(/agents/agent-1/status) -> [code:200] [allow:] [body:any:map[id:agent-1]]
+	//test: Handle(/agents/agent-1) -> [code:405] [allow:DELETE, PUT] [body:{"type":"about:blank","title":"Method Not Allowed","status":405,"instance":"/agents/agent-1","request-id":"123"}]
+	//test: Handle(/agents) -> [code:404] [allow:] [body:{"type":"about:blank","title":"Not Found","status":404,"instance":"/agents","request-id":"123"}]
+
+}
+
+func ExampleExpandPath() {
+	path, err := ExpandPath("/agents/{id}/tasks/{task}", map[string]string{"id": "agent 1", "task": "100"})
+	fmt.Printf("test: ExpandPath() -> [path:%v] [err:%v]\n", path, err)
+
+	_, err = ExpandPath("/agents/{id}/tasks/{task}", map[string]string{"id": "agent-1"})
+	fmt.Printf("test: ExpandPath() -> [err:%v]\n", err)
+
+	//Output:
+	//test: ExpandPath() -> [path:/agents/agent%201/tasks/100] [err:<nil>]
+	//test: ExpandPath() -> [err:invalid argument: path parameter is missing [task]]
+
+}
@@ -0,0 +1,376 @@
+package http2
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"io"
+	"iter"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ContentTypeEventStream = "text/event-stream"
+	LastEventId            = "Last-Event-ID"
+	CacheControl           = "Cache-Control"
+
+	DefaultHeartbeat  = time.Second * 15
+	DefaultRetryDelay = time.Second * 3
+)
+
+var (
+	sseWriteLoc = PkgUri + "/EventWriter"
+	sseReadLoc  = PkgUri + "/Events"
+
+	// StreamClient - the default client for event streams, there is no overall timeout
+	StreamClient, _ = NewClientBuilder().Timeout(0).Build()
+)
+
+// Event - server-sent event, data that is not a string or []byte is JSON
+type Event[T any] struct {
+	Id    string
+	Event string
+	Data  T
+	Retry time.Duration
+}
+
+// EventWriter - server-sent event writer, each event is flushed, and the writer stops when the client disconnects
+type EventWriter struct {
+	w      http.ResponseWriter
+	ctx    context.Context
+	stop   chan struct{}
+	done   chan struct{}
+	closed bool
+	status *runtime.Status
+	mu     sync.Mutex
+}
+
+// NewEventWriter - create an event writer, writing the response headers. The response writer must support
+// flushing.
+func NewEventWriter(w http.ResponseWriter, r *http.Request) (*EventWriter, *runtime.Status) {
+	if _, ok := w.(http.Flusher); !ok {
+		return nil, runtime.NewStatusError(http.StatusInternalServerError, sseWriteLoc, errors.New("invalid argument: response writer does not support flushing"))
+	}
+	ew := &EventWriter{w: w, ctx: r.Context(), status: runtime.NewStatusOK()}
+	w.Header().Set(ContentType, ContentTypeEventStream)
+	w.Header().Set(CacheControl, "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	w.(http.Flusher).Flush()
+	return ew, ew.status
+}
+
+// Send - send an event, the status is not OK once the client disconnects or a write fails, and an id or event
+// name with a line break is an invalid argument
+func (ew *EventWriter) Send(id, event string, data any) *runtime.Status {
+	return ew.send(id, event, 0, data)
+}
+
+func (ew *EventWriter) send(id, event string, retry time.Duration, data any) *runtime.Status {
+	var buf []byte
+
+	// A line break would start another field or event
+	if strings.ContainsAny(id, "\r\n") || strings.ContainsAny(event, "\r\n") {
+		return runtime.NewStatusError(runtime.StatusInvalidArgument, sseWriteLoc, errors.New(fmt.Sprintf("invalid argument: event id or name contains a line break [%q] [%q]", id, event)))
+	}
+	switch ptr := data.(type) {
+	case string:
+		buf = []byte(ptr)
+	case []byte:
+		buf = ptr
+	default:
+		var err error
+		if buf, err = json.Marshal(data); err != nil {
+			return runtime.NewStatusError(runtime.StatusJsonEncodeError, sseWriteLoc, err)
+		}
+	}
+	var sb strings.Builder
+	if id != "" {
+		sb.WriteString("id: " + id + "\n")
+	}
+	if event != "" {
+		sb.WriteString("event: " + event + "\n")
+	}
+	if retry > 0 {
+		sb.WriteString(fmt.Sprintf("retry: %v\n", retry.Milliseconds()))
+	}
+	for _, line := range dataLines(string(buf)) {
+		sb.WriteString("data: " + line + "\n")
+	}
+	sb.WriteString("\n")
+	return ew.write(sb.String())
+}
+
+// dataLines - split data on each of the CRLF, CR and LF line terminators
+func dataLines(data string) []string {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	return strings.Split(strings.ReplaceAll(data, "\r", "\n"), "\n")
+}
+
+// Retry - send the client reconnect delay
+func (ew *EventWriter) Retry(d time.Duration) *runtime.Status {
+	return ew.write(fmt.Sprintf("retry: %v\n\n", d.Milliseconds()))
+}
+
+// Comment - send a comment, which is ignored by clients
+func (ew *EventWriter) Comment(s string) *runtime.Status {
+	return ew.write(": " + s + "\n\n")
+}
+
+// Heartbeat - send a comment at an interval, to keep the connection open, until the writer is closed or the
+// client disconnects
+func (ew *EventWriter) Heartbeat(interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultHeartbeat
+	}
+	ew.mu.Lock()
+	if ew.stop != nil || ew.closed {
+		ew.mu.Unlock()
+		return
+	}
+	ew.stop = make(chan struct{})
+	ew.done = make(chan struct{})
+	stop, done := ew.stop, ew.done
+	ew.mu.Unlock()
+	go func() {
+		defer close(done)
+		tick := time.NewTicker(interval)
+		defer tick.Stop()
+		for {
+			select {
+			case <-tick.C:
+				if !ew.Comment("heartbeat").OK() {
+					return
+				}
+			case <-stop:
+				return
+			case <-ew.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Close - stop the heartbeat, waiting for it to stop, and return the final status, nothing is written after the
+// writer is closed
+func (ew *EventWriter) Close() *runtime.Status {
+	ew.mu.Lock()
+	ew.closed = true
+	stop, done := ew.stop, ew.done
+	ew.stop = nil
+	status := ew.status
+	ew.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	return status
+}
+
+func (ew *EventWriter) write(s string) *runtime.Status {
+	ew.mu.Lock()
+	defer ew.mu.Unlock()
+	if ew.closed || !ew.status.OK() {
+		return ew.status
+	}
+	if err := ew.ctx.Err(); err != nil {
+		ew.status = runtime.NewStatus(runtime.StatusCancelled).AddLocation(sseWriteLoc)
+		return ew.status
+	}
+	if _, err := io.WriteString(ew.w, s); err != nil {
+		ew.status = runtime.NewStatusError(runtime.StatusIOError, sseWriteLoc, err)
+		return ew.status
+	}
+	ew.w.(http.Flusher).Flush()
+	return ew.status
+}
+
+// StreamEvents - templated function writing the events from a channel, with heartbeats, until the channel is
+// closed or the client disconnects. An event that cannot be encoded, or has a line break in its id or name, is
+// skipped, and it and the final status are handled by the error handler.
+func StreamEvents[E runtime.ErrorHandler, T any](w http.ResponseWriter, r *http.Request, events <-chan Event[T], heartbeat time.Duration) *runtime.Status {
+	var e E
+
+	ew, status := NewEventWriter(w, r)
+	if !status.OK() {
+		return e.Handle(status, runtime.RequestId(r), sseWriteLoc)
+	}
+	ew.Heartbeat(heartbeat)
+	defer ew.Close()
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return ew.Close()
+			}
+			status = ew.send(ev.Id, ev.Event, ev.Retry, ev.Data)
+			if status.Code() == runtime.StatusJsonEncodeError || status.Code() == runtime.StatusInvalidArgument {
+				e.Handle(status, runtime.RequestId(r), sseWriteLoc)
+				continue
+			}
+			if !status.OK() {
+				return e.Handle(status, runtime.RequestId(r), sseWriteLoc)
+			}
+		case <-r.Context().Done():
+			return e.Handle(runtime.NewStatus(runtime.StatusCancelled).AddLocation(sseWriteLoc), runtime.RequestId(r), sseWriteLoc)
+		}
+	}
+}
+
+// StreamOptions - options for an event stream, the reconnects are the number of consecutive times to reconnect
+// after the stream ends or fails without an event, and the retry delay is used until the server sends one
+type StreamOptions struct {
+	Options
+	Reconnects int
+	RetryDelay time.Duration
+}
+
+// ReadEvents - templated function reading the events in a response body, as decompressed by Do, the body is
+// closed when the events are read, or the iteration stops
+func ReadEvents[T any](resp *http.Response) iter.Seq2[Event[T], *runtime.Status] {
+	return func(yield func(Event[T], *runtime.Status) bool) {
+		if resp == nil || resp.Body == nil {
+			yield(Event[T]{}, runtime.NewStatusError(runtime.StatusInvalidContent, sseReadLoc, errors.New("invalid argument: response or body is nil")))
+			return
+		}
+		defer resp.Body.Close()
+		var lastId string
+		var retry time.Duration
+		readEvents[T](resp.Body, &lastId, &retry, yield)
+	}
+}
+
+// Events - templated function for a GET request returning an event stream, reconnecting with the Last-Event-ID
+// header. A failure is yielded with an error status, and the stream ends when the reconnects are exhausted, the
+// server responds with no content, the context is done, or the iteration stops. The reconnects are reset by a
+// connection that yields an event. StreamClient is used unless a client is in the options.
+func Events[T any](ctx context.Context, uri string, opts *StreamOptions) iter.Seq2[Event[T], *runtime.Status] {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts == nil {
+		opts = new(StreamOptions)
+	}
+	return func(yield func(Event[T], *runtime.Status) bool) {
+		var lastId string
+		retry := opts.RetryDelay
+		if retry <= 0 {
+			retry = DefaultRetryDelay
+		}
+		for attempt := 0; attempt <= opts.Reconnects; attempt++ {
+			if attempt > 0 {
+				select {
+				case <-time.After(retry):
+				case <-ctx.Done():
+					return
+				}
+			}
+			req, status := newVerbRequest(ctx, http.MethodGet, uri, nil, &opts.Options)
+			if !status.OK() {
+				yield(Event[T]{}, status)
+				return
+			}
+			req.Header.Set(Accept, ContentTypeEventStream)
+			if lastId != "" {
+				req.Header.Set(LastEventId, lastId)
+			}
+			if _, ok := ClientFromContext(req.Context()); !ok {
+				req = req.WithContext(NewClientContext(req.Context(), StreamClient))
+			}
+			resp, status := Do(req)
+			// A server stops reconnects with no content
+			if resp != nil && resp.StatusCode == http.StatusNoContent {
+				if resp.Body != nil {
+					resp.Body.Close()
+				}
+				return
+			}
+			if resp == nil || resp.StatusCode != http.StatusOK {
+				if !yield(Event[T]{}, errorStatus(resp, status)) {
+					return
+				}
+				continue
+			}
+			delivered := false
+			cont := readEvents[T](resp.Body, &lastId, &retry, func(ev Event[T], status *runtime.Status) bool {
+				delivered = delivered || status.OK()
+				return yield(ev, status)
+			})
+			resp.Body.Close()
+			if !cont || ctx.Err() != nil {
+				return
+			}
+			if delivered {
+				attempt = 0
+			}
+		}
+	}
+}
+
+// readEvents - parse an event stream, updating the last event id and retry delay, and returning false if the
+// iteration stopped
+func readEvents[T any](r io.Reader, lastId *string, retry *time.Duration, yield func(Event[T], *runtime.Status) bool) bool {
+	var ev Event[T]
+	var data []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if len(data) > 0 {
+				ev.Id = *lastId
+				status := decodeEventData(strings.Join(data, "\n"), &ev.Data)
+				if !yield(ev, status) {
+					return false
+				}
+			}
+			ev = Event[T]{}
+			data = nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			*lastId = value
+		case "event":
+			ev.Event = value
+		case "data":
+			data = append(data, value)
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil {
+				ev.Retry = time.Duration(ms) * time.Millisecond
+				*retry = ev.Retry
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return yield(Event[T]{}, runtime.NewStatusError(runtime.StatusIOError, sseReadLoc, err))
+	}
+	return true
+}
+
+func decodeEventData[T any](data string, t *T) *runtime.Status {
+	switch ptr := any(t).(type) {
+	case *string:
+		*ptr = data
+	case *[]byte:
+		*ptr = []byte(data)
+	default:
+		if err := json.Unmarshal([]byte(data), t); err != nil {
+			return runtime.NewStatusError(runtime.StatusJsonDecodeError, sseReadLoc, err)
+		}
+	}
+	return runtime.NewStatusOK()
+}
@@ -0,0 +1,200 @@
+package http2
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type progress struct {
+	Step  int
+	Total int
+}
+
+func ExampleReadEvents() {
+	body := ": comment\nretry: 100\n\nid: 1\nevent: progress\ndata: {\"Step\":1,\ndata: \"Total\":2}\n\ndata: invalid\n\n"
+	resp := &http.Response{Body: io.NopCloser(strings.NewReader(body))}
+	for ev, status := range ReadEvents[progress](resp) {
+		fmt.Printf("test: ReadEvents() -> [id:%v] [event:%v] [data:%v] [status:%v]\n", ev.Id, ev.Event, ev.Data, status.Code())
+	}
+
+	//Output:
+	//test: ReadEvents() -> [id:1] [event:progress] [data:{1 2}] [status:200]
+	//test: ReadEvents() -> [id:1] [event:] [data:{0 0}] [status:92]
+
+}
+
+func ExampleEvents() {
+	s := httptest.NewServer(NewHandler[runtime.BypassError](func(ctx context.Context, w http.ResponseWriter, r *http.Request) *runtime.Status {
+		events := make(chan Event[progress], 3)
+		if r.Header.Get(LastEventId) == "" {
+			events <- Event[progress]{Id: "1", Event: "progress", Data: progress{1, 3}, Retry: time.Millisecond * 10}
+			events <- Event[progress]{Id: "2", Event: "progress", Data: progress{2, 3}}
+		} else {
+			events <- Event[progress]{Id: "3", Event: "done", Data: progress{3, 3}}
+		}
+		close(events)
+		return StreamEvents[runtime.BypassError](w, r, events, time.Minute)
+	}))
+	defer s.Close()
+
+	for ev, status := range Events[progress](context.Background(), s.URL, &StreamOptions{Reconnects: 1}) {
+		fmt.Printf("test: Events() -> [id:%v] [event:%v] [data:%v] [retry:%v] [status:%v]\n", ev.Id, ev.Event, ev.Data, ev.Retry, status)
+		if ev.Event == "done" {
+			break
+		}
+	}
+
+	//Output:
+	//test: Events() -> [id:1] [event:progress] [data:{1 3}] [retry:10ms] [status:OK]
+	//test: Events() -> [id:2] [event:progress] [data:{2 3}] [retry:0s] [status:OK]
+	//test: Events() -> [id:3] [event:done] [data:{3 3}] [retry:0s] [status:OK]
+
+}
+
+func ExampleEvents_reconnect() {
+	var connections atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := connections.Add(1)
+		if r.URL.Path == "/done" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set(ContentType, ContentTypeEventStream)
+		fmt.Fprintf(w, "id: %v\ndata: connection %v\n\n", n, n)
+	}))
+	defer s.Close()
+
+	opts := &StreamOptions{Reconnects: 1, RetryDelay: time.Millisecond * 10}
+	count := 0
+	for ev, status := range Events[string](context.Background(), s.URL, opts) {
+		fmt.Printf("test: Events() -> [id:%v] [data:%v] [status:%v]\n", ev.Id, ev.Data, status)
+		if count++; count == 4 {
+			break
+		}
+	}
+
+	connections.Store(0)
+	count = 0
+	for range Events[string](context.Background(), s.URL+"/done", opts) {
+		count++
+	}
+	fmt.Printf("test: Events(no-content) -> [events:%v] [connections:%v]\n", count, connections.Load())
+
+	//Output:
+	//test: Events() -> [id:1] [data:connection 1] [status:OK]
+	//test: Events() -> [id:2] [data:connection 2] [status:OK]
+	//test: Events() -> [id:3] [data:connection 3] [status:OK]
+	//test: Events() -> [id:4] [data:connection 4] [status:OK]
+	//test: Events(no-content) -> [events:0] [connections:1]
+
+}
+
+// syncRecorder - recorder that can be read while a heartbeat is writing
+type syncRecorder struct {
+	*httptest.ResponseRecorder
+	mu sync.Mutex
+}
+
+func (r *syncRecorder) Write(buf []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ResponseRecorder.Write(buf)
+}
+
+func (r *syncRecorder) WriteString(str string) (int, error) {
+	return r.Write([]byte(str))
+}
+
+func (r *syncRecorder) heartbeats() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return strings.Count(r.Body.String(), ": heartbeat\n\n")
+}
+
+func ExampleEventWriter_Heartbeat() {
+	w := &syncRecorder{ResponseRecorder: httptest.NewRecorder()}
+	ew, _ := NewEventWriter(w, httptest.NewRequest(http.MethodGet, "/events", nil))
+	ew.Heartbeat(time.Millisecond * 10)
+	time.Sleep(time.Millisecond * 55)
+	status := ew.Close()
+	n := w.heartbeats()
+	_, stopped := <-ew.done
+	time.Sleep(time.Millisecond * 30)
+	fmt.Printf("test: Heartbeat(close) -> [status:%v] [heartbeats:%v] [stopped:%v] [after-close:%v]\n", status, n >= 2, !stopped, w.heartbeats()-n)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w = &syncRecorder{ResponseRecorder: httptest.NewRecorder()}
+	ew, _ = NewEventWriter(w, httptest.NewRequest(http.MethodGet, "/events", nil).WithContext(ctx))
+	ew.Heartbeat(time.Millisecond * 10)
+	time.Sleep(time.Millisecond * 35)
+	cancel()
+	select {
+	case <-ew.done:
+		fmt.Printf("test: Heartbeat(disconnect) -> [heartbeats:%v] [stopped:true]\n", w.heartbeats() >= 2)
+	case <-time.After(time.Second):
+		fmt.Printf("test: Heartbeat(disconnect) -> [stopped:false]\n")
+	}
+
+	//Output:
+	//test: Heartbeat(close) -> [status:OK] [heartbeats:true] [stopped:true] [after-close:0]
+	//test: Heartbeat(disconnect) -> [heartbeats:true] [stopped:true]
+
+}
+
+func ExampleEventWriter_Send() {
+	w := httptest.NewRecorder()
+	ew, _ := NewEventWriter(w, httptest.NewRequest(http.MethodGet, "/events", nil))
+	w.Body.Reset()
+
+	status := ew.Send("1\nevent: injected", "message", "data")
+	fmt.Printf("test: Send(id) -> [status:%v]\n", status.Code())
+	status = ew.Send("1", "message\r\ndata: injected", "data")
+	fmt.Printf("test: Send(event) -> [status:%v]\n", status.Code())
+	status = ew.Send("2", "message", "line 1\r\nline 2\rline 3\nline 4")
+	fmt.Printf("test: Send(data) -> [status:%v] [body:%q]\n", status, w.Body.String())
+
+	//Output:
+	//test: Send(id) -> [status:3]
+	//test: Send(event) -> [status:3]
+	//test: Send(data) -> [status:OK] [body:"id: 2\nevent: message\ndata: line 1\ndata: line 2\ndata: line 3\ndata: line 4\n\n"]
+
+}
+
+type cancelRecorder struct {
+	*httptest.ResponseRecorder
+	cancel func()
+}
+
+func (r cancelRecorder) Write(buf []byte) (int, error) {
+	defer r.cancel()
+	return r.ResponseRecorder.Write(buf)
+}
+
+func (r cancelRecorder) WriteString(str string) (int, error) {
+	return r.Write([]byte(str))
+}
+
+func ExampleStreamEvents() {
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/events", nil).WithContext(ctx)
+	w := cancelRecorder{ResponseRecorder: httptest.NewRecorder(), cancel: cancel}
+	events := make(chan Event[string], 1)
+	events <- Event[string]{Event: "message", Data: "line 1\nline 2"}
+
+	status := StreamEvents[runtime.BypassError](w, req, events, time.Minute)
+	fmt.Printf("test: StreamEvents() -> [status:%v] [content-type:%v]\n", status, w.Header().Get(ContentType))
+	fmt.Printf("test: StreamEvents() -> [body:%q]\n", w.Body.String())
+
+	//Output:
+	//test: StreamEvents() -> [status:Cancelled] [content-type:text/event-stream]
+	//test: StreamEvents() -> [body:"event: message\ndata: line 1\ndata: line 2\n\n"]
+
+}
@@ -0,0 +1,174 @@
+package http2
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"github.com/go-ai-agent/core/runtime"
+	"io"
+	"iter"
+	"net/http"
+)
+
+const (
+	ContentTypeNdjson = "application/x-ndjson"
+)
+
+var (
+	streamReadLoc  = PkgUri + "/DeserializeStream"
+	streamWriteLoc = PkgUri + "/StreamWriter"
+)
+
+// DeserializeStream - templated function providing streaming deserialization of a request/response body, of
+// newline-delimited JSON, or the elements of a top-level JSON array. A T is yielded per element, and an error
+// is yielded with a zero T and an error status, which ends the stream. The body is closed when the stream ends,
+// or the iteration stops. The body is not decompressed, a response body from Do is already decompressed.
+func DeserializeStream[T any](body io.ReadCloser) iter.Seq2[T, *runtime.Status] {
+	return func(yield func(T, *runtime.Status) bool) {
+		var t T
+
+		if body == nil {
+			yield(t, runtime.NewStatusError(runtime.StatusInvalidContent, streamReadLoc, errors.New("body is nil")))
+			return
+		}
+		defer body.Close()
+		r := bufio.NewReader(body)
+		array, err := isJsonArray(r)
+		if err != nil {
+			if err != io.EOF {
+				yield(t, runtime.NewStatusError(runtime.StatusIOError, streamReadLoc, err))
+			}
+			return
+		}
+		dec := json.NewDecoder(r)
+		if array {
+			if _, err = dec.Token(); err != nil {
+				yield(t, runtime.NewStatusError(runtime.StatusJsonDecodeError, streamReadLoc, err))
+				return
+			}
+		}
+		for !array || dec.More() {
+			var t1 T
+
+			if err = dec.Decode(&t1); err != nil {
+				if err == io.EOF && !array {
+					return
+				}
+				yield(t, runtime.NewStatusError(runtime.StatusJsonDecodeError, streamReadLoc, err))
+				return
+			}
+			if !yield(t1, runtime.NewStatusOK()) {
+				return
+			}
+		}
+		if _, err = dec.Token(); err != nil {
+			yield(t, runtime.NewStatusError(runtime.StatusJsonDecodeError, streamReadLoc, err))
+		}
+	}
+}
+
+// isJsonArray - determine if the first non-whitespace byte starts a JSON array, returning io.EOF for an empty body
+func isJsonArray(r *bufio.Reader) (bool, error) {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return false, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b == '[', r.UnreadByte()
+	}
+}
+
+// StreamWriter - streaming writer for newline-delimited JSON, or a JSON array, each element is flushed
+type StreamWriter struct {
+	w     http.ResponseWriter
+	array bool
+	count int
+	err   error
+}
+
+// NewStreamWriter - create a stream writer, writing the response headers
+func NewStreamWriter(w http.ResponseWriter, array bool, code int) *StreamWriter {
+	ct := ContentTypeNdjson
+	if array {
+		ct = ContentTypeJson
+	}
+	w.Header().Set(ContentType, ct)
+	w.WriteHeader(code)
+	return &StreamWriter{w: w, array: array}
+}
+
+// Write - write an element, the status is not OK once a write fails
+func (sw *StreamWriter) Write(t any) *runtime.Status {
+	if sw.err != nil {
+		return runtime.NewStatusError(runtime.StatusIOError, streamWriteLoc, sw.err)
+	}
+	buf, err := json.Marshal(t)
+	if err != nil {
+		return runtime.NewStatusError(runtime.StatusJsonEncodeError, streamWriteLoc, err)
+	}
+	switch {
+	case !sw.array:
+		buf = append(buf, '\n')
+	case sw.count == 0:
+		buf = append([]byte{'['}, buf...)
+	default:
+		buf = append([]byte{','}, buf...)
+	}
+	if _, sw.err = sw.w.Write(buf); sw.err != nil {
+		return runtime.NewStatusError(runtime.StatusIOError, streamWriteLoc, sw.err)
+	}
+	sw.count++
+	if f, ok := sw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return runtime.NewStatusOK()
+}
+
+// Close - end a JSON array
+func (sw *StreamWriter) Close() *runtime.Status {
+	if !sw.array || sw.err != nil {
+		return runtime.NewStatusOK()
+	}
+	s := "]"
+	if sw.count == 0 {
+		s = "[]"
+	}
+	if _, sw.err = io.WriteString(sw.w, s); sw.err != nil {
+		return runtime.NewStatusError(runtime.StatusIOError, streamWriteLoc, sw.err)
+	}
+	return runtime.NewStatusOK()
+}
+
+// WriteStream - templated function writing the elements of a sequence, as newline-delimited JSON, or a JSON
+// array, as negotiated from the request Accept header. An error status from the sequence ends the stream, and
+// a JSON array is not ended, so that a client decoding fails. The status is handled by the error handler. A nil
+// request accepts either content type.
+func WriteStream[E runtime.ErrorHandler, T any](w http.ResponseWriter, r *http.Request, seq iter.Seq2[T, *runtime.Status]) *runtime.Status {
+	var e E
+
+	accept, requestId := "", ""
+	if r != nil {
+		accept = r.Header.Get(Accept)
+		requestId = runtime.RequestId(r)
+	}
+	ct, ok := Negotiate(accept, []string{ContentTypeNdjson, ContentTypeJson})
+	if !ok {
+		writeProblem[E](w, NewProblem(runtime.NewStatus(http.StatusNotAcceptable).SetRequestId(requestId), r))
+		return runtime.NewStatus(http.StatusNotAcceptable)
+	}
+	w.Header().Add(Vary, Accept)
+	sw := NewStreamWriter(w, ct == ContentTypeJson, http.StatusOK)
+	for t, status := range seq {
+		if !status.OK() {
+			return e.Handle(status, requestId, streamWriteLoc)
+		}
+		if status = sw.Write(t); !status.OK() {
+			return e.Handle(status, requestId, streamWriteLoc)
+		}
+	}
+	return e.Handle(sw.Close(), requestId, streamWriteLoc)
+}
@@ -0,0 +1,113 @@
+package http2
+
+import (
+	"compress/gzip"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"io"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+// closeRecorder - body recording that it was closed
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func ExampleDeserializeStream() {
+	for _, body := range []string{
+		"{\"Step\":1,\"Total\":2}\n{\"Step\":2,\"Total\":2}\n",
+		" [{\"Step\":1,\"Total\":3},{\"Step\":2,\"Total\":3},{\"Step\":3,\"Total\":3}]",
+		"[{\"Step\":1,\"Total\":2},{\"Step\":",
+		"",
+	} {
+		var steps []int
+		var last *runtime.Status
+		for t, status := range DeserializeStream[progress](io.NopCloser(strings.NewReader(body))) {
+			if status.OK() {
+				steps = append(steps, t.Step)
+			}
+			last = status
+		}
+		fmt.Printf("test: DeserializeStream() -> [steps:%v] [status:%v]\n", steps, last)
+	}
+
+	// Early termination
+	count := 0
+	body := &closeRecorder{Reader: strings.NewReader("[{\"Step\":1},{\"Step\":2},{\"Step\":3}]")}
+	for range DeserializeStream[progress](body) {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	fmt.Printf("test: DeserializeStream() -> [count:%v] [closed:%v]\n", count, body.closed)
+
+	// Gzip response
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(ContentType, ContentTypeNdjson)
+		w.Header().Set(ContentEncoding, GzipEncoding)
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte("{\"Step\":1,\"Total\":2}\n{\"Step\":2,\"Total\":2}\n"))
+		zw.Close()
+	}))
+	defer s.Close()
+	req, _ := http.NewRequest(http.MethodGet, s.URL, nil)
+	req.Header.Set(AcceptEncoding, GzipEncoding)
+	resp, _ := Do(req)
+	var steps []int
+	var last *runtime.Status
+	for t, status := range DeserializeStream[progress](resp.Body) {
+		steps = append(steps, t.Step)
+		last = status
+	}
+	fmt.Printf("test: DeserializeStream(gzip) -> [steps:%v] [status:%v]\n", steps, last)
+
+	//Output:
+	//test: DeserializeStream() -> [steps:[1 2]] [status:OK]
+	//test: DeserializeStream() -> [steps:[1 2 3]] [status:OK]
+	//test: DeserializeStream() -> [steps:[1]] [status:Json Decode Failure [unexpected EOF]]
+	//test: DeserializeStream() -> [steps:[]] [status:<nil>]
+	//test: DeserializeStream() -> [count:2] [closed:true]
+	//test: DeserializeStream(gzip) -> [steps:[1 2]] [status:OK]
+
+}
+
+func progressSeq(n int) iter.Seq2[progress, *runtime.Status] {
+	return func(yield func(progress, *runtime.Status) bool) {
+		for i := 1; i <= n; i++ {
+			if !yield(progress{Step: i, Total: n}, runtime.NewStatusOK()) {
+				return
+			}
+		}
+	}
+}
+
+func ExampleWriteStream() {
+	for _, accept := range []string{"", ContentTypeJson, "text/html"} {
+		req := httptest.NewRequest(http.MethodGet, "/progress", nil)
+		req.Header.Set(Accept, accept)
+		w := httptest.NewRecorder()
+		status := WriteStream[runtime.BypassError](w, req, progressSeq(2))
+		fmt.Printf("test: WriteStream(%v) -> [status:%v] [content-type:%v] [body:%q]\n", accept, status.Code(), w.Header().Get(ContentType), w.Body.String())
+	}
+
+	w := httptest.NewRecorder()
+	status := WriteStream[runtime.BypassError](w, nil, progressSeq(1))
+	fmt.Printf("test: WriteStream(nil) -> [status:%v] [body:%q]\n", status.Code(), w.Body.String())
+
+	//Output:
+	//test: WriteStream() -> [status:200] [content-type:application/x-ndjson] [body:"{\"Step\":1,\"Total\":2}\n{\"Step\":2,\"Total\":2}\n"]
+	//test: WriteStream(application/json) -> [status:200] [content-type:application/json] [body:"[{\"Step\":1,\"Total\":2},{\"Step\":2,\"Total\":2}]"]
+	//test: WriteStream(text/html) -> [status:406] [content-type:application/problem+json] [body:"{\"type\":\"about:blank\",\"title\":\"Not Acceptable\",\"status\":406,\"instance\":\"/progress\"}"]
+	//test: WriteStream(nil) -> [status:200] [body:"{\"Step\":1,\"Total\":1}\n"]
+
+}
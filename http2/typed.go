@@ -0,0 +1,110 @@
+package http2
+
+import (
+	"bytes"
+	"context"
+	"github.com/go-ai-agent/core/runtime"
+	"io"
+	"net/http"
+)
+
+var (
+	typedHttpLoc = PkgUri + "/TypedHttp"
+	typedDoLoc   = PkgUri + "/TypedDo"
+)
+
+// NoBody - request type for a TypedHandler that does not read a request body
+type NoBody struct{}
+
+// TypedHandler - generic handler with a decoded request and a typed response
+type TypedHandler[Req, Resp any] func(ctx context.Context, r *http.Request, req Req) (Resp, *runtime.Status)
+
+// StatusCode - the HTTP status code for a status, request decoding and validation failures are client errors:
+//
+//	StatusInvalidArgument, StatusInvalidContent, StatusJsonDecodeError, StatusNotProvided - 400 Bad Request
+//	StatusRateLimited                                                                    - 429 Too Many Requests
+//	nil                                                                                  - 200 OK
+//
+// All other codes are mapped by runtime.Status.Http.
+func StatusCode(status *runtime.Status) int {
+	if status == nil {
+		return http.StatusOK
+	}
+	switch status.Code() {
+	case runtime.StatusInvalidArgument, runtime.StatusInvalidContent, runtime.StatusJsonDecodeError, runtime.StatusNotProvided:
+		return http.StatusBadRequest
+	case runtime.StatusRateLimited:
+		return http.StatusTooManyRequests
+	}
+	return status.Http()
+}
+
+// TypedHttp - templated function adapting a TypedHandler to a runtime.HttpHandler. The request body is decoded
+// with Deserialize, and the response is written with WriteNegotiated. A failing status is handled by the error
+// handler, and written as a problem.
+func TypedHttp[E runtime.ErrorHandler, Req, Resp any](h TypedHandler[Req, Resp]) runtime.HttpHandler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) *runtime.Status {
+		var e E
+
+		if ctx == nil {
+			ctx = runtime.HandlerContext(nil, r)
+		}
+		req, status := decodeRequest[Req](r.Body)
+		if status.OK() {
+			var resp Resp
+
+			resp, status = h(ctx, r, req)
+			if status.OK() {
+				WriteNegotiated[E](w, r, any(resp), status, nil)
+				return status
+			}
+		}
+		status = e.Handle(status, runtime.RequestId(r), typedHttpLoc)
+		writeProblem[E](w, NewProblem(status, r))
+		return status
+	}
+}
+
+// TypedDo - adapt a TypedHandler to a runtime.DoHandler, the body can be a Req, or bytes or a reader to decode.
+// A nil body decodes the request body.
+func TypedDo[Req, Resp any](h TypedHandler[Req, Resp]) runtime.DoHandler {
+	return func(ctx any, r *http.Request, body any) (any, *runtime.Status) {
+		var req Req
+		var status *runtime.Status
+
+		switch ptr := body.(type) {
+		case Req:
+			req, status = ptr, runtime.NewStatusOK()
+		case nil:
+			if r == nil {
+				req, status = decodeRequest[Req](nil)
+			} else {
+				req, status = decodeRequest[Req](r.Body)
+			}
+		case []byte:
+			req, status = decodeRequest[Req](io.NopCloser(bytes.NewReader(ptr)))
+		case io.ReadCloser:
+			req, status = decodeRequest[Req](ptr)
+		case io.Reader:
+			req, status = decodeRequest[Req](io.NopCloser(ptr))
+		default:
+			status = runtime.NewStatusError(runtime.StatusInvalidContent, typedDoLoc, runtime.NewInvalidBodyTypeError(body))
+		}
+		if !status.OK() {
+			return nil, status
+		}
+		return h(runtime.HandlerContext(ctx, r), r, req)
+	}
+}
+
+func decodeRequest[Req any](body io.ReadCloser) (Req, *runtime.Status) {
+	var req Req
+
+	if _, ok := any(req).(NoBody); ok {
+		return req, runtime.NewStatusOK()
+	}
+	if body == http.NoBody {
+		body = nil
+	}
+	return Deserialize[Req](body)
+}
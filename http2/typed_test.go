@@ -0,0 +1,100 @@
+package http2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+type searchReq struct {
+	Query string
+}
+
+type searchResp struct {
+	Query string
+	Count int
+}
+
+func search(ctx context.Context, r *http.Request, req searchReq) (*searchResp, *runtime.Status) {
+	if req.Query == "" {
+		return nil, runtime.NewStatusError(runtime.StatusInvalidArgument, "/search", errors.New("query is empty"))
+	}
+	return &searchResp{Query: req.Query, Count: 2}, runtime.NewStatusOK()
+}
+
+func ExampleTypedHttp() {
+	h := TypedHttp[runtime.BypassError](search)
+
+	w := httptest.NewRecorder()
+	status := h(nil, w, httptest.NewRequest(http.MethodPost, "/search", strings.NewReader(`{"Query":"golang"}`)))
+	fmt.Printf("test: TypedHttp() -> [status:%v] [code:%v] [content-type:%v] [body:%v]\n", status, w.Code, w.Result().Header.Get(ContentType), w.Body.String())
+
+	w = httptest.NewRecorder()
+	status = h(nil, w, httptest.NewRequest(http.MethodPost, "/search", strings.NewReader(`{"Query":""}`)))
+	fmt.Printf("test: TypedHttp() -> [status:%v] [code:%v]\n", status.Code(), w.Code)
+
+	w = httptest.NewRecorder()
+	status = h(nil, w, httptest.NewRequest(http.MethodPost, "/search", strings.NewReader(`{"Query":`)))
+	fmt.Printf("test: TypedHttp() -> [status:%v] [code:%v]\n", status.Code(), w.Code)
+
+	w = httptest.NewRecorder()
+	status = TypedHttp[runtime.BypassError](func(ctx context.Context, r *http.Request, req NoBody) (string, *runtime.Status) {
+		return "pong", runtime.NewStatusOK()
+	})(nil, w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	fmt.Printf("test: TypedHttp() -> [status:%v] [code:%v] [body:%v]\n", status, w.Code, w.Body.String())
+
+	//Output:
+	//test: TypedHttp() -> [status:OK] [code:200] [content-type:application/json] [body:{"Query":"golang","Count":2}]
+	//test: TypedHttp() -> [status:3] [code:400]
+	//test: TypedHttp() -> [status:92] [code:400]
+	//test: TypedHttp() -> [status:OK] [code:200] [body:pong]
+
+}
+
+func ExampleTypedDo() {
+	h := TypedDo[searchReq, *searchResp](search)
+
+	t, status := h(nil, nil, searchReq{Query: "golang"})
+	fmt.Printf("test: TypedDo(searchReq) -> [t:%v] [status:%v]\n", t, status)
+
+	t, status = h(nil, nil, []byte(`{"Query":"net/http"}`))
+	fmt.Printf("test: TypedDo([]byte) -> [t:%v] [status:%v]\n", t, status)
+
+	req := httptest.NewRequest(http.MethodPost, "/search", strings.NewReader(`{"Query":"context"}`))
+	t, status = h(nil, req, nil)
+	fmt.Printf("test: TypedDo(nil) -> [t:%v] [status:%v]\n", t, status)
+
+	_, status = h(nil, nil, 100)
+	fmt.Printf("test: TypedDo(int) -> [status:%v]\n", status)
+
+	//Output:
+	//test: TypedDo(searchReq) -> [t:&{golang 2}] [status:OK]
+	//test: TypedDo([]byte) -> [t:&{net/http 2}] [status:OK]
+	//test: TypedDo(nil) -> [t:&{context 2}] [status:OK]
+	//test: TypedDo(int) -> [status:Invalid Content [invalid body type: int]]
+
+}
+
+func ExampleStatusCode() {
+	for _, status := range []*runtime.Status{
+		nil,
+		runtime.NewStatus(runtime.StatusInvalidArgument),
+		runtime.NewStatus(runtime.StatusNotProvided),
+		runtime.NewStatus(runtime.StatusRateLimited),
+		runtime.NewStatus(http.StatusNotFound),
+	} {
+		fmt.Printf("test: StatusCode(%v) -> [%v]\n", status, StatusCode(status))
+	}
+
+	//Output:
+	//test: StatusCode(<nil>) -> [200]
+	//test: StatusCode(Invalid Argument) -> [400]
+	//test: StatusCode(Not Provided) -> [400]
+	//test: StatusCode(Rate Limited) -> [429]
+	//test: StatusCode(Not Found) -> [404]
+
+}
@@ -0,0 +1,202 @@
+package http2
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/go-ai-agent/core/io2"
+	"github.com/go-ai-agent/core/json2"
+	"github.com/go-ai-agent/core/runtime"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	Accept = "Accept"
+
+	// errorBodyLimit - the maximum number of bytes read from an upstream error body
+	errorBodyLimit = 64 * 1024
+)
+
+var (
+	verbLoc       = PkgUri + "/Verb"
+	defaultHeader http.Header
+	defaultMu     sync.RWMutex
+)
+
+// Options - request options for the verb helpers, the header is added after the default headers, and the client,
+// or the named client, is used instead of the client selected by Do
+type Options struct {
+	Header     http.Header
+	Query      url.Values
+	Client     *http.Client
+	ClientName string
+}
+
+func (o *Options) client() *http.Client {
+	if o.Client != nil {
+		return o.Client
+	}
+	if c, ok := LookupClient(o.ClientName); ok {
+		return c
+	}
+	return nil
+}
+
+// SetDefaultHeaders - set the headers added to all verb helper requests
+func SetDefaultHeaders(h http.Header) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultHeader = h.Clone()
+}
+
+func getDefaultHeaders() http.Header {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultHeader
+}
+
+// Get - templated function for a GET request, decoding the response into T
+func Get[T any](ctx any, uri string, opts *Options) (T, *runtime.Status) {
+	return exchange[T](ctx, http.MethodGet, uri, nil, opts)
+}
+
+// Post - templated function for a POST request, marshalling the request body and decoding the response into Resp
+func Post[Req, Resp any](ctx any, uri string, body Req, opts *Options) (Resp, *runtime.Status) {
+	return send[Req, Resp](ctx, http.MethodPost, uri, body, opts)
+}
+
+// Put - templated function for a PUT request, marshalling the request body and decoding the response into Resp
+func Put[Req, Resp any](ctx any, uri string, body Req, opts *Options) (Resp, *runtime.Status) {
+	return send[Req, Resp](ctx, http.MethodPut, uri, body, opts)
+}
+
+// Delete - templated function for a DELETE request, decoding the response into T
+func Delete[T any](ctx any, uri string, opts *Options) (T, *runtime.Status) {
+	return exchange[T](ctx, http.MethodDelete, uri, nil, opts)
+}
+
+func send[Req, Resp any](ctx any, method, uri string, body Req, opts *Options) (Resp, *runtime.Status) {
+	var resp Resp
+	var buf []byte
+	var status *runtime.Status
+
+	switch ptr := any(body).(type) {
+	case []byte:
+		buf, status = ptr, runtime.NewStatusOK()
+	default:
+		buf, status = json2.Marshal(body)
+	}
+	if !status.OK() {
+		return resp, status.AddLocation(verbLoc)
+	}
+	return exchange[Resp](ctx, method, uri, buf, opts)
+}
+
+// exchange - send a request, a 2xx response is decoded and the status has the response code, and a non-2xx
+// response is an error status with the response body
+func exchange[T any](ctx any, method, uri string, body []byte, opts *Options) (T, *runtime.Status) {
+	var t T
+
+	req, status := newVerbRequest(ctx, method, uri, body, opts)
+	if !status.OK() {
+		return t, status
+	}
+	resp, status := Do(req)
+	if status.IsErrors() && resp != nil && resp.Body != nil {
+		resp.Body.Close()
+		return t, status
+	}
+	if resp == nil || resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return t, errorStatus(resp, status)
+	}
+	if resp.StatusCode == http.StatusNoContent || resp.ContentLength == 0 {
+		if resp.Body != nil {
+			resp.Body.Close()
+		}
+		return t, runtime.NewStatus(resp.StatusCode)
+	}
+	t, status = Deserialize[T](resp.Body)
+	if resp.Body != nil {
+		resp.Body.Close()
+	}
+	if !status.OK() {
+		return t, status
+	}
+	return t, runtime.NewStatus(resp.StatusCode)
+}
+
+// newVerbRequest - create a request with the query parameters, default and option headers, and option client
+func newVerbRequest(ctx any, method, uri string, body []byte, opts *Options) (*http.Request, *runtime.Status) {
+	var r io.Reader
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, runtime.NewStatusError(runtime.StatusInvalidArgument, verbLoc, err)
+	}
+	if opts != nil && len(opts.Query) > 0 {
+		q := u.Query()
+		for k, v := range opts.Query {
+			for _, s := range v {
+				q.Add(k, s)
+			}
+		}
+		u.RawQuery = q.Encode()
+	}
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, status := NewRequest(ctx, method, u, "", r)
+	if !status.OK() {
+		return nil, status
+	}
+	copyHeader(req.Header, getDefaultHeaders())
+	if opts != nil {
+		copyHeader(req.Header, opts.Header)
+		if c := opts.client(); c != nil {
+			req = req.WithContext(NewClientContext(req.Context(), c))
+		}
+	}
+	if body != nil && req.Header.Get(ContentType) == "" {
+		req.Header.Set(ContentType, ContentTypeJson)
+	}
+	if req.Header.Get(Accept) == "" {
+		req.Header.Set(Accept, ContentTypeJson)
+	}
+	return req, status
+}
+
+// errorStatus - an error status for a non-2xx response, with the response body, as decompressed by Do, read up
+// to errorBodyLimit bytes
+func errorStatus(resp *http.Response, status *runtime.Status) *runtime.Status {
+	if status.IsErrors() || resp == nil || resp.Body == nil {
+		return status
+	}
+	body := resp.Body
+	buf, status0 := io2.ReadAll(readCloser{Reader: io.LimitReader(body, errorBodyLimit), close: body.Close})
+	if !status0.OK() {
+		return runtime.NewStatusError(resp.StatusCode, verbLoc, status0.Errors()...)
+	}
+	msg := strings.TrimSpace(string(buf))
+	if msg == "" {
+		msg = http.StatusText(resp.StatusCode)
+	}
+	status = runtime.NewStatusError(resp.StatusCode, verbLoc, errors.New(fmt.Sprintf("upstream error: %v [%v]", resp.StatusCode, msg)))
+	if len(buf) > 0 {
+		status.SetContent(buf, false)
+		status.SetContentType(resp.Header.Get(ContentType))
+	}
+	return status
+}
+
+func copyHeader(dst, src http.Header) {
+	for k, v := range src {
+		dst.Del(k)
+		for _, s := range v {
+			dst.Add(k, s)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package http2
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+)
+
+type agent struct {
+	Id   string
+	Name string
+}
+
+func verbServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Query().Get("id") == "large" {
+				w.Header().Set(ContentEncoding, GzipEncoding)
+				w.WriteHeader(http.StatusBadGateway)
+				zw := gzip.NewWriter(w)
+				zw.Write([]byte(strings.Repeat("x", errorBodyLimit*2)))
+				zw.Close()
+				return
+			}
+			if r.URL.Query().Get("id") == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"id is missing"}`))
+				return
+			}
+			json.NewEncoder(w).Encode(agent{Id: r.URL.Query().Get("id"), Name: r.Header.Get("x-agent")})
+		case http.MethodPost, http.MethodPut:
+			var a agent
+			json.NewDecoder(r.Body).Decode(&a)
+			a.Name = r.Header.Get(ContentType) + "|" + r.Header.Get(Accept)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(a)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+}
+
+func ExampleGet() {
+	s := verbServer()
+	defer s.Close()
+	SetDefaultHeaders(http.Header{"X-Agent": []string{"default"}})
+	defer SetDefaultHeaders(nil)
+
+	a, status := Get[agent](nil, s.URL, &Options{Query: url.Values{"id": []string{"agent-1"}}})
+	fmt.Printf("test: Get() -> [agent:%v] [status:%v]\n", a, status)
+
+	a, status = Get[agent](nil, s.URL, &Options{Query: url.Values{"id": []string{"agent-2"}}, Header: http.Header{"X-Agent": []string{"override"}}})
+	fmt.Printf("test: Get() -> [agent:%v] [status:%v]\n", a, status)
+
+	_, status = Get[agent](nil, s.URL, nil)
+	fmt.Printf("test: Get() -> [status:%v] [content:%v]\n", status, status.ContentString())
+
+	_, status = Get[agent](nil, s.URL, &Options{Query: url.Values{"id": []string{"large"}}, Header: http.Header{AcceptEncoding: []string{GzipEncoding}}})
+	content := status.ContentString()
+	fmt.Printf("test: Get(large) -> [status:%v] [content:%v] [decompressed:%v]\n", status.Code(), len(content), strings.Trim(content, "x") == "")
+
+	//Output:
+	//test: Get() -> [agent:{agent-1 default}] [status:OK]
+	//test: Get() -> [agent:{agent-2 override}] [status:OK]
+	//test: Get() -> [status:Bad Request [upstream error: 400 [{"error":"id is missing"}]]] [content:{"error":"id is missing"}]
+	//test: Get(large) -> [status:502] [content:65536] [decompressed:true]
+
+}
+
+func ExamplePost() {
+	s := verbServer()
+	defer s.Close()
+
+	a, status := Post[agent, agent](nil, s.URL, agent{Id: "agent-1"}, nil)
+	fmt.Printf("test: Post() -> [agent:%v] [status:%v]\n", a, status.Code())
+
+	a, status = Put[[]byte, agent](nil, s.URL, []byte(`{"Id":"agent-2"}`), nil)
+	fmt.Printf("test: Put() -> [agent:%v] [status:%v]\n", a, status.Code())
+
+	_, status = Delete[agent](nil, s.URL, nil)
+	fmt.Printf("test: Delete() -> [status:%v]\n", status.Code())
+
+	//Output:
+	//test: Post() -> [agent:{agent-1 application/json|application/json}] [status:201]
+	//test: Put() -> [agent:{agent-2 application/json|application/json}] [status:201]
+	//test: Delete() -> [status:204]
+
+}
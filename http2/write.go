@@ -13,6 +13,7 @@ var (
 
 // WriteResponse - write a http.Response, utilizing the content, status, and headers
 // Only supports []byte, string, io.Reader, and io.ReaderCloser for T
+// Use WriteNegotiated for content negotiation and compression
 func WriteResponse[E runtime.ErrorHandler](w http.ResponseWriter, content any, status *runtime.Status, headers any) {
 	var e E
 
@@ -1,11 +1,13 @@
 package log2
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/go-ai-agent/core/runtime"
 	strings2 "github.com/go-ai-agent/core/strings"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -21,6 +23,8 @@ func fmtLog(traffic string, start time.Time, duration time.Duration, req *http.R
 		host = req.URL.Host
 	}
 	d := int(duration / time.Duration(1e6))
+	o := runtime.GetOrigin()
+	from := runtime.OriginFromHeader(req.Header)
 	s := fmt.Sprintf("{ \"traffic\":\"%v\", "+
 		"\"start\":%v, "+
 		"\"duration\":%v, "+
@@ -32,7 +36,15 @@ func fmtLog(traffic string, start time.Time, duration time.Duration, req *http.R
 		"\"path\":%v, "+
 		"\"status-code\":%v, "+
 		"\"threshold\":%v, "+
-		"\"status-flags\":%v }",
+		"\"status-flags\":%v, "+
+		"\"region\":%v, "+
+		"\"zone\":%v, "+
+		"\"sub-zone\":%v, "+
+		"\"service\":%v, "+
+		"\"instance-id\":%v, "+
+		"\"from-region\":%v, "+
+		"\"from-zone\":%v, "+
+		"\"from-service\":%v }",
 		traffic,
 		strings2.FmtTimestamp(start),
 		strconv.Itoa(d),
@@ -49,14 +61,32 @@ func fmtLog(traffic string, start time.Time, duration time.Duration, req *http.R
 
 		threshold,
 		fmtstr(statusFlags),
+
+		fmtstr(o.Region),
+		fmtstr(o.Zone),
+		fmtstr(o.SubZone),
+		fmtstr(o.Service),
+		fmtstr(o.InstanceId),
+
+		fmtstr(from.Region),
+		fmtstr(from.Zone),
+		fmtstr(from.Service),
 	)
 
 	return s
 }
 
+// fmtstr - quote and escape a value, values such as headers are from callers, and must not be able to add
+// or change fields
 func fmtstr(value string) string {
 	if len(value) == 0 {
 		return "null"
 	}
-	return "\"" + value + "\""
+	var sb strings.Builder
+	enc := json.NewEncoder(&sb)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return "null"
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/go-ai-agent/core/runtime"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -20,3 +21,17 @@ func Example_FmtLog() {
 	//Output:
 
 }
+
+func Example_fmtLogEscape() {
+	req, _ := http.NewRequest(http.MethodGet, "https://www.google.com/search?q=test&lang=en", nil)
+	req.Header.Set(runtime.OriginRegionKey, "us-west\", \"from-zone\":\"forged")
+	req.Header.Set(runtime.OriginServiceKey, "search\nforged")
+	s := fmtLog("ingress", time.Now().UTC(), 0, req, nil, -1, "")
+	fmt.Printf("test: fmtLog() -> [url:%v]\n", strings.Contains(s, "\"url\":\"https://www.google.com/search?q=test&lang=en\""))
+	fmt.Printf("test: fmtLog() -> %v\n", s[strings.Index(s, "\"from-region\""):])
+
+	//Output:
+	//test: fmtLog() -> [url:true]
+	//test: fmtLog() -> "from-region":"us-west\", \"from-zone\":\"forged", "from-zone":null, "from-service":"search\nforged" }
+
+}
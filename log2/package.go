@@ -79,22 +79,42 @@ func AnyAccess(traffic string, start time.Time, duration time.Duration, req *htt
 	}
 }
 
-// WrapDo - wrap a DoHandler with access logging
+// WrapDo - wrap a DoHandler with access logging, a panic is recovered and logged
 func WrapDo(handler runtime.DoHandler) runtime.DoHandler {
+	return WrapDoWith[runtime.LogError](handler)
+}
+
+// WrapDoWith - templated function wrapping a DoHandler with access logging, a panic is recovered and handled by
+// the error handler
+func WrapDoWith[E runtime.ErrorHandler](handler runtime.DoHandler) runtime.DoHandler {
 	return func(ctx any, req *http.Request, body any) (any, *runtime.Status) {
 		var start = time.Now().UTC()
 
 		if handler == nil {
 			return nil, runtime.NewStatusError(runtime.StatusInvalidArgument, PkgUri+"/WrapDo", errors.New("error:Do handler function is nil for access log")).SetRequestId(req.Context())
 		}
-		data, status := handler(ctx, req, body)
-		AnyAccess(InternalTraffic, start, time.Since(start), req, &http.Response{StatusCode: status.Code()}, -1, "")
+		data, status := runtime.RecoverDo[E](handler)(ctx, req, body)
+		AnyAccess(InternalTraffic, start, time.Since(start), req, &http.Response{StatusCode: status.Code()}, -1, panicFlag(status))
 		return data, status
 	}
 }
 
-// WrapPost - wrap a PostHandler with access logging
+// panicFlag - access log status flag for a status created from a recovered panic
+func panicFlag(status *runtime.Status) string {
+	if runtime.IsPanicStatus(status) {
+		return runtime.PanicFlag
+	}
+	return ""
+}
+
+// WrapPost - wrap a PostHandler with access logging, a panic is recovered and logged
 func WrapPost(handler runtime.PostHandler) runtime.PostHandler {
+	return WrapPostWith[runtime.LogError](handler)
+}
+
+// WrapPostWith - templated function wrapping a PostHandler with access logging, a panic is recovered and handled
+// by the error handler
+func WrapPostWith[E runtime.ErrorHandler](handler runtime.PostHandler) runtime.PostHandler {
 	return func(ctx any, r *http.Request, body any) (any, *runtime.Status) {
 		var start = time.Now().UTC()
 
@@ -102,22 +122,28 @@ func WrapPost(handler runtime.PostHandler) runtime.PostHandler {
 		if handler == nil {
 			return nil, runtime.NewStatusError(runtime.StatusInvalidArgument, PkgUri+"/WrapPost", errors.New("error:Do handler function is nil for access log")).SetRequestId(r)
 		}
-		data, status := handler(ctx, r, body)
-		AnyAccess(InternalTraffic, start, time.Since(start), r, &http.Response{StatusCode: status.Code()}, -1, "")
+		data, status := runtime.RecoverPost[E](handler)(ctx, r, body)
+		AnyAccess(InternalTraffic, start, time.Since(start), r, &http.Response{StatusCode: status.Code()}, -1, panicFlag(status))
 		return data, status
 	}
 }
 
-// WrapHttp - wrap a HttpHandler with access logging
+// WrapHttp - wrap a HttpHandler with access logging, a panic is recovered and logged
 func WrapHttp(handler runtime.HttpHandler) runtime.HttpHandler {
+	return WrapHttpWith[runtime.LogError](handler)
+}
+
+// WrapHttpWith - templated function wrapping a HttpHandler with access logging, a panic is recovered and handled
+// by the error handler
+func WrapHttpWith[E runtime.ErrorHandler](handler runtime.HttpHandler) runtime.HttpHandler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) *runtime.Status {
 		var start = time.Now().UTC()
 
 		if handler == nil {
 			return runtime.NewStatusError(runtime.StatusInvalidArgument, PkgUri+"/WrapHttp", errors.New("error:Http handler function is nil for access log")).SetRequestId(r.Context())
 		}
-		status := handler(ctx, w, r)
-		AnyAccess(InternalTraffic, start, time.Since(start), r, &http.Response{StatusCode: status.Code()}, -1, "")
+		status := runtime.RecoverHttp[E](handler)(ctx, w, r)
+		AnyAccess(InternalTraffic, start, time.Since(start), r, &http.Response{StatusCode: status.Code()}, -1, panicFlag(status))
 		return status
 	}
 }
@@ -132,6 +158,21 @@ func WrapBypass(handler runtime.DoHandler) runtime.DoHandler {
 	}
 }
 
+// DoMiddleware - access logging middleware for a DoHandler chain
+func DoMiddleware() runtime.Middleware[runtime.DoHandler] {
+	return WrapDo
+}
+
+// PostMiddleware - access logging middleware for a PostHandler chain
+func PostMiddleware() runtime.Middleware[runtime.PostHandler] {
+	return WrapPost
+}
+
+// HttpMiddleware - access logging middleware for a HttpHandler chain
+func HttpMiddleware() runtime.Middleware[runtime.HttpHandler] {
+	return WrapHttp
+}
+
 // AddRequestId - function copied from package httpx
 func AddRequestId(req *http.Request) string {
 	if req == nil {
@@ -1,5 +1,38 @@
 package log2
 
+import (
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"net/http"
+	"time"
+)
+
 func Example_LogAccess() {
 	// w := WrapDo[defaultLogFn](nil,nil,nil)
 }
+
+func ExampleWrapDo_panic() {
+	var flags string
+	prev := GetAccessHandler()
+	SetAccessHandler(func(traffic string, start time.Time, duration time.Duration, req *http.Request, resp *http.Response, threshold int, statusFlags string) {
+		flags = statusFlags
+	})
+	defer func() { handler = prev }()
+
+	req, _ := http.NewRequest(http.MethodGet, "https://www.google.com/search?q=golang", nil)
+	_, status := WrapDo(func(ctx any, r *http.Request, body any) (any, *runtime.Status) {
+		panic("do failure")
+	})(nil, req, nil)
+	fmt.Printf("test: WrapDo() -> [status:%v] [handled:%v] [flags:%v]\n", status.Code(), status.ErrorsHandled(), flags)
+
+	flags = ""
+	_, status = WrapDoWith[runtime.BypassError](func(ctx any, r *http.Request, body any) (any, *runtime.Status) {
+		panic("do failure")
+	})(nil, req, nil)
+	fmt.Printf("test: WrapDoWith() -> [status:%v] [handled:%v] [flags:%v]\n", status.Code(), status.ErrorsHandled(), flags)
+
+	//Output:
+	//test: WrapDo() -> [status:500] [handled:true] [flags:PA]
+	//test: WrapDoWith() -> [status:500] [handled:false] [flags:PA]
+
+}
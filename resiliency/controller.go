@@ -29,11 +29,18 @@ type controller struct {
 	name      string
 	threshold Threshold
 	handler   runtime.DoHandler
+	recoverFn func(handler runtime.DoHandler) runtime.DoHandler
 	log       startup.AccessLogFn
 }
 
-// NewController - create a new resiliency controller
+// NewController - create a new resiliency controller, a panic in the handler is recovered and logged
 func NewController(name string, threshold Threshold, handler runtime.DoHandler, log startup.AccessLogFn) Controller {
+	return NewControllerWith[runtime.LogError](name, threshold, handler, log)
+}
+
+// NewControllerWith - templated function to create a new resiliency controller, a panic in the handler is
+// recovered and handled by the error handler
+func NewControllerWith[E runtime.ErrorHandler](name string, threshold Threshold, handler runtime.DoHandler, log startup.AccessLogFn) Controller {
 	//if handler == nil {
 	//	return nil, errors.New("error: handler is nil")
 	//}
@@ -41,10 +48,31 @@ func NewController(name string, threshold Threshold, handler runtime.DoHandler,
 	ctrl.name = name
 	ctrl.threshold = threshold
 	ctrl.handler = handler
+	ctrl.recoverFn = runtime.RecoverDo[E]
 	ctrl.log = log
 	return ctrl
 }
 
+// ControllerMiddleware - create a middleware applying a new controller to the next handler in a chain. A handler
+// context is carried to the next handler as the request context.
+func ControllerMiddleware(name string, threshold Threshold, log startup.AccessLogFn) runtime.Middleware[runtime.DoHandler] {
+	return ControllerMiddlewareWith[runtime.LogError](name, threshold, log)
+}
+
+// ControllerMiddlewareWith - templated function to create a middleware applying a new controller, handling a
+// panic with the error handler, to the next handler in a chain
+func ControllerMiddlewareWith[E runtime.ErrorHandler](name string, threshold Threshold, log startup.AccessLogFn) runtime.Middleware[runtime.DoHandler] {
+	return func(next runtime.DoHandler) runtime.DoHandler {
+		c := NewControllerWith[E](name, threshold, next, log)
+		return func(ctx any, r *http.Request, body any) (any, *runtime.Status) {
+			if ctx2, ok := ctx.(context.Context); ok && ctx2 != nil && r != nil {
+				r = r.WithContext(ctx2)
+			}
+			return c.Apply(r, body)
+		}
+	}
+}
+
 func (c *controller) failover() {
 	//failoverState := true
 	done := make(chan struct{})
@@ -89,7 +117,10 @@ func (c *controller) Apply(r *http.Request, body any) (any, *runtime.Status) {
 	if c.handler == nil {
 		return nil, runtime.NewStatusError(runtime.StatusInvalidArgument, "/Controller/Apply", errors.New(fmt.Sprintf("error: handler function is nil for controller [%v]", c.name))).SetRequestId(r.Context())
 	}
-	t, status := callHandler(r, body, c.handler, c.threshold.Timeout)
+	t, status := callHandler(r, body, c.recoverFn(c.handler), c.threshold.Timeout)
+	if runtime.IsPanicStatus(status) {
+		statusFlags = runtime.PanicFlag
+	}
 	resp := http.Response{StatusCode: status.Code()}
 	d := time.Since(start)
 	if c.log != nil {
@@ -0,0 +1,169 @@
+package runtime
+
+import (
+	"fmt"
+	"github.com/go-ai-agent/core/strings"
+	"log"
+	"sort"
+	strings2 "strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultDedupInterval = time.Second * 30
+	DefaultDedupLimit    = 1
+	SuppressedName       = "suppressed"
+)
+
+// DedupOptions - DedupError configuration. Errors are grouped by code, location and error text, and Limit is the
+// number of errors for a group that are output in each Interval. LimitFn, if set, is called with the first status
+// of a group, and overrides Limit for the group when it returns a limit greater than 0. The remaining errors are
+// counted, and a summary is output at the end of the interval.
+type DedupOptions struct {
+	Interval time.Duration
+	Limit    int
+	LimitFn  func(s *Status) int
+	Output   func(s string)
+}
+
+type dedupEntry struct {
+	status     *Status
+	limit      int
+	count      int
+	suppressed int
+}
+
+type deduper struct {
+	opts  DedupOptions
+	m     map[string]*dedupEntry
+	timer *time.Timer
+	mu    sync.Mutex
+}
+
+var dedup = newDeduper(DedupOptions{})
+
+func newDeduper(opts DedupOptions) *deduper {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultDedupInterval
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultDedupLimit
+	}
+	if opts.Output == nil {
+		opts.Output = func(s string) { log.Println(s) }
+	}
+	return &deduper{opts: opts, m: make(map[string]*dedupEntry)}
+}
+
+// SetDedupOptions - configure DedupError, any pending summaries are output first
+func SetDedupOptions(opts DedupOptions) {
+	FlushDedupErrors()
+	d := newDeduper(opts)
+	dedupMu.Lock()
+	defer dedupMu.Unlock()
+	dedup = d
+}
+
+var dedupMu sync.RWMutex
+
+func getDeduper() *deduper {
+	dedupMu.RLock()
+	defer dedupMu.RUnlock()
+	return dedup
+}
+
+// FlushDedupErrors - output the summaries of suppressed errors, and start a new interval
+func FlushDedupErrors() {
+	getDeduper().flush()
+}
+
+func dedupKey(s *Status) string {
+	var errs []string
+	for _, e := range s.Errors() {
+		if e != nil {
+			errs = append(errs, e.Error())
+		}
+	}
+	return fmt.Sprintf("%v|%v|%v", s.Code(), strings2.Join(s.Location(), ","), strings2.Join(errs, ","))
+}
+
+func (d *deduper) handle(s *Status) {
+	key := dedupKey(s)
+	d.mu.Lock()
+	e, ok := d.m[key]
+	if !ok {
+		e = &dedupEntry{status: s, limit: d.limit(s)}
+		d.m[key] = e
+	}
+	e.count++
+	output := e.count <= e.limit
+	if !output {
+		e.suppressed++
+	}
+	if d.timer == nil {
+		d.timer = time.AfterFunc(d.opts.Interval, d.flush)
+	}
+	d.mu.Unlock()
+	if output {
+		d.opts.Output(formatter(s))
+	}
+}
+
+// limit - the limit for the group of a status
+func (d *deduper) limit(s *Status) int {
+	if d.opts.LimitFn != nil {
+		if n := d.opts.LimitFn(s); n > 0 {
+			return n
+		}
+	}
+	return d.opts.Limit
+}
+
+func (d *deduper) flush() {
+	d.mu.Lock()
+	var summaries []string
+	for key, e := range d.m {
+		if e.suppressed > 0 {
+			summaries = append(summaries, formatSummary(e.status, e.suppressed, d.opts.Interval))
+		}
+		delete(d.m, key)
+	}
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	d.mu.Unlock()
+	sort.Strings(summaries)
+	for _, s := range summaries {
+		d.opts.Output(s)
+	}
+}
+
+func formatSummary(s *Status, suppressed int, interval time.Duration) string {
+	return fmt.Sprintf("{ %v, %v, %v, %v, %v, %v }",
+		strings.JsonMarkup(StatusCodeName, fmt.Sprintf("%v", s.Code()), false),
+		strings.JsonMarkup(StatusName, s.Description(), true),
+		FormatTrace(TraceName, s.Location()),
+		FormatErrors(ErrorsName, s.Errors()),
+		strings.JsonMarkup(SuppressedName, fmt.Sprintf("%v", suppressed), false),
+		strings.JsonMarkup("interval", interval.String(), true))
+}
+
+// DedupError - deduplicating error handler. The first errors for a code, location and error text are output
+// immediately, up to the configured limit, and the remaining errors in the interval are output as a summary with
+// a count.
+type DedupError struct{}
+
+func (h DedupError) Handle(s *Status, requestId string, callerLocation string) *Status {
+	if s == nil {
+		return s
+	}
+	s.SetRequestId(requestId)
+	s.AddLocation(callerLocation)
+	if s.IsErrors() && !s.ErrorsHandled() {
+		getDeduper().handle(s)
+		s.SetErrorsHandled()
+	}
+	return s
+}
@@ -0,0 +1,78 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func ExampleDedupError() {
+	var h DedupError
+	SetDedupOptions(DedupOptions{Interval: time.Hour, Limit: 2, Output: func(s string) { fmt.Printf("test: Output() -> %v\n", s) }})
+	defer SetDedupOptions(DedupOptions{})
+
+	for i := 0; i < 5; i++ {
+		h.Handle(NewStatusError(http.StatusServiceUnavailable, "/database", errors.New("connection refused")), "", "/query")
+	}
+	h.Handle(NewStatusError(http.StatusGatewayTimeout, "/cache", errors.New("timeout")), "", "/query")
+	s := h.Handle(NewStatusError(http.StatusGatewayTimeout, "/cache", errors.New("timeout")), "", "/query")
+	fmt.Printf("test: Handle() -> [handled:%v]\n", s.ErrorsHandled())
+
+	s = NewStatusError(http.StatusGatewayTimeout, "/cache", errors.New("timeout"))
+	s.SetErrorsHandled()
+	h.Handle(s, "", "/query")
+
+	FlushDedupErrors()
+	h.Handle(NewStatusError(http.StatusServiceUnavailable, "/database", errors.New("connection refused")), "", "/query")
+
+	//Output:
+	//test: Output() -> { "code":503, "status":"Service Unavailable", "request-id":null, "trace" : [ "/query","/database" ], "errors" : [ "connection refused" ] }
+	//
+	//test: Output() -> { "code":503, "status":"Service Unavailable", "request-id":null, "trace" : [ "/query","/database" ], "errors" : [ "connection refused" ] }
+	//
+	//test: Output() -> { "code":504, "status":"Timeout", "request-id":null, "trace" : [ "/query","/cache" ], "errors" : [ "timeout" ] }
+	//
+	//test: Output() -> { "code":504, "status":"Timeout", "request-id":null, "trace" : [ "/query","/cache" ], "errors" : [ "timeout" ] }
+	//
+	//test: Handle() -> [handled:true]
+	//test: Output() -> { "code":503, "status":"Service Unavailable", "trace" : [ "/query","/database" ], "errors" : [ "connection refused" ], "suppressed":3, "interval":"1h0m0s" }
+	//test: Output() -> { "code":503, "status":"Service Unavailable", "request-id":null, "trace" : [ "/query","/database" ], "errors" : [ "connection refused" ] }
+
+}
+
+func ExampleDedupOptions_limitFn() {
+	var h DedupError
+	var output []string
+	SetDedupOptions(DedupOptions{Interval: time.Hour, Limit: 1,
+		LimitFn: func(s *Status) int {
+			if s.Code() == http.StatusGatewayTimeout {
+				return 3
+			}
+			return 0
+		},
+		Output: func(s string) {
+			summary := strings.Contains(s, SuppressedName)
+			output = append(output, fmt.Sprintf("[%v] [summary:%v]", s[:strings.Index(s, ",")], summary))
+		}})
+	defer SetDedupOptions(DedupOptions{})
+
+	for i := 0; i < 5; i++ {
+		h.Handle(NewStatusError(http.StatusServiceUnavailable, "/database", errors.New("connection refused")), "", "/query")
+		h.Handle(NewStatusError(http.StatusGatewayTimeout, "/cache", errors.New("timeout")), "", "/query")
+	}
+	FlushDedupErrors()
+	for _, s := range output {
+		fmt.Printf("test: Output() -> %v\n", s)
+	}
+
+	//Output:
+	//test: Output() -> [{ "code":503] [summary:false]
+	//test: Output() -> [{ "code":504] [summary:false]
+	//test: Output() -> [{ "code":504] [summary:false]
+	//test: Output() -> [{ "code":504] [summary:false]
+	//test: Output() -> [{ "code":503] [summary:true]
+	//test: Output() -> [{ "code":504] [summary:true]
+
+}
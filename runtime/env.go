@@ -9,6 +9,12 @@ import (
 
 const (
 	EnvPrefix = "$"
+	EnvFlag   = "env"
+
+	DebugEnv = "debug"
+	TestEnv  = "test"
+	StageEnv = "stage"
+	ProdEnv  = "prod"
 )
 
 type runtimeEnv int
@@ -21,9 +27,25 @@ const (
 )
 
 var (
-	rte = debug
+	// EnvKey - the environment variable read by LoadEnvironment, which can be changed before it is called
+	EnvKey = "RUNTIME_ENV"
+
+	rte = defaultEnv()
 )
 
+// defaultEnv - production for a prod build, otherwise debug
+func defaultEnv() runtimeEnv {
+	if prodBuild {
+		return production
+	}
+	return debug
+}
+
+// IsProdBuild - determine if the binary was built with the prod build tag, which disables the debug environment
+func IsProdBuild() bool {
+	return prodBuild
+}
+
 func IsProdEnvironment() bool {
 	return rte == production
 }
@@ -49,7 +71,75 @@ func SetStageEnvironment() {
 }
 
 func IsDebugEnvironment() bool {
-	return rte == debug
+	return !prodBuild && rte == debug
+}
+
+// SetEnvironment - set the environment by name: debug, test, stage or prod. The debug environment is refused for
+// a prod build.
+func SetEnvironment(name string) error {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case DebugEnv:
+		if prodBuild {
+			return errors.New("invalid argument: debug environment is not allowed in a prod build")
+		}
+		rte = debug
+	case TestEnv:
+		rte = test
+	case StageEnv:
+		rte = stage
+	case ProdEnv, "production":
+		rte = production
+	default:
+		return errors.New(fmt.Sprintf("invalid argument: environment is invalid [%v]", name))
+	}
+	return nil
+}
+
+// LoadEnvironment - set the environment from a "-env" flag in the arguments, or from the EnvKey environment
+// variable. The flag takes precedence, and if neither is set the environment is unchanged.
+func LoadEnvironment(args []string) error {
+	if name, ok := lookupFlag(args, EnvFlag); ok {
+		return SetEnvironment(name)
+	}
+	if name, ok := os.LookupEnv(EnvKey); ok && name != "" {
+		return SetEnvironment(name)
+	}
+	return nil
+}
+
+// lookupFlag - find a flag value in the forms -name=value, --name=value, -name value and --name value
+func lookupFlag(args []string, name string) (string, bool) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		s := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		if s == arg {
+			continue
+		}
+		if s == name && i+1 < len(args) {
+			return args[i+1], true
+		}
+		if strings.HasPrefix(s, name+"=") {
+			return s[len(name)+1:], true
+		}
+	}
+	return "", false
+}
+
+// EnvValues - values by environment name, with DefaultEnvValue as the key of a value for all other environments
+type EnvValues[T any] map[string]T
+
+const (
+	DefaultEnvValue = "*"
+)
+
+// Value - the value for the current environment, or the default value
+func (v EnvValues[T]) Value() T {
+	if t, ok := v[EnvStr()]; ok {
+		return t
+	}
+	return v[DefaultEnvValue]
 }
 
 func LookupEnv(name string) (string, error) {
@@ -62,13 +152,13 @@ func LookupEnv(name string) (string, error) {
 func EnvStr() string {
 	switch rte {
 	case debug:
-		return "debug"
+		return DebugEnv
 	case test:
-		return "test"
+		return TestEnv
 	case stage:
-		return "stage"
+		return StageEnv
 	case production:
-		return "prod"
+		return ProdEnv
 	}
 	return "unknown"
 }
@@ -0,0 +1,5 @@
+//go:build !prod
+
+package runtime
+
+const prodBuild = false
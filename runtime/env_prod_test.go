@@ -0,0 +1,33 @@
+//go:build prod
+
+package runtime
+
+import (
+	"fmt"
+)
+
+func Example_prodBuild() {
+	fmt.Printf("test: IsProdEnvironment() -> %v\n", IsProdEnvironment())
+	fmt.Printf("test: IsDebugEnvironment() -> %v\n", IsDebugEnvironment())
+
+	rte = debug
+	fmt.Printf("test: IsDebugEnvironment() -> %v\n", IsDebugEnvironment())
+	rte = production
+
+	//Output:
+	//test: IsProdEnvironment() -> true
+	//test: IsDebugEnvironment() -> false
+	//test: IsDebugEnvironment() -> false
+
+}
+
+func ExampleSetEnvironment_debug() {
+	defer SetEnvironment(EnvStr())
+	SetEnvironment(StageEnv)
+
+	fmt.Printf("test: SetEnvironment(debug) -> [err:%v] [env:%v] [prod-build:%v]\n", SetEnvironment("debug"), EnvStr(), IsProdBuild())
+
+	//Output:
+	//test: SetEnvironment(debug) -> [err:invalid argument: debug environment is not allowed in a prod build] [env:stage] [prod-build:true]
+
+}
@@ -5,36 +5,6 @@ import (
 	"os"
 )
 
-func Example_RuntimeEnv() {
-	fmt.Printf("test: IsProdEnvironment() -> %v\n", IsProdEnvironment())
-	fmt.Printf("test: IsTestEnvironment() -> %v\n", IsTestEnvironment())
-	fmt.Printf("test: IsStageEnvironment() -> %v\n", IsStageEnvironment())
-	fmt.Printf("test: IsDebugEnvironment() -> %v\n", IsDebugEnvironment())
-
-	SetProdEnvironment()
-	fmt.Printf("test: IsProdEnvironment() -> %v\n", IsProdEnvironment())
-
-	SetTestEnvironment()
-	fmt.Printf("test: IsTestEnvironment() -> %v\n", IsTestEnvironment())
-
-	SetStageEnvironment()
-	fmt.Printf("test: IsStageEnvironment() -> %v\n", IsStageEnvironment())
-
-	rte = debug
-	fmt.Printf("test: IsDebugEnvironment() -> %v\n", IsDebugEnvironment())
-
-	//Output:
-	//test: IsProdEnvironment() -> false
-	//test: IsTestEnvironment() -> false
-	//test: IsStageEnvironment() -> false
-	//test: IsDebugEnvironment() -> true
-	//test: IsProdEnvironment() -> true
-	//test: IsTestEnvironment() -> true
-	//test: IsStageEnvironment() -> true
-	//test: IsDebugEnvironment() -> true
-
-}
-
 func ExampleLookupEnv() {
 	name := ""
 
@@ -54,3 +24,65 @@ func ExampleLookupEnv() {
 	//test: LookupEnv() -> [err:<nil>][DEV]
 
 }
+
+func ExampleSetEnvironment() {
+	defer SetEnvironment(EnvStr())
+
+	fmt.Printf("test: SetEnvironment(stage) -> [err:%v] [env:%v]\n", SetEnvironment("stage"), EnvStr())
+	fmt.Printf("test: SetEnvironment(production) -> [err:%v] [env:%v]\n", SetEnvironment("Production"), EnvStr())
+	fmt.Printf("test: SetEnvironment(invalid) -> [err:%v] [env:%v]\n", SetEnvironment("dev"), EnvStr())
+
+	//Output:
+	//test: SetEnvironment(stage) -> [err:<nil>] [env:stage]
+	//test: SetEnvironment(production) -> [err:<nil>] [env:prod]
+	//test: SetEnvironment(invalid) -> [err:invalid argument: environment is invalid [dev]] [env:prod]
+
+}
+
+func ExampleLoadEnvironment() {
+	defer SetEnvironment(EnvStr())
+	SetEnvironment(StageEnv)
+
+	fmt.Printf("test: LoadEnvironment(nil) -> [err:%v] [env:%v]\n", LoadEnvironment(nil), EnvStr())
+
+	os.Setenv(EnvKey, "test")
+	defer os.Unsetenv(EnvKey)
+	fmt.Printf("test: LoadEnvironment(%v) -> [err:%v] [env:%v]\n", EnvKey, LoadEnvironment([]string{"-port=8080"}), EnvStr())
+
+	args := []string{"-port=8080", "--env", "stage"}
+	fmt.Printf("test: LoadEnvironment(%v) -> [err:%v] [env:%v]\n", args, LoadEnvironment(args), EnvStr())
+
+	args = []string{"-env=prod"}
+	fmt.Printf("test: LoadEnvironment(%v) -> [err:%v] [env:%v]\n", args, LoadEnvironment(args), EnvStr())
+
+	key := EnvKey
+	EnvKey = "APP_ENV"
+	defer func() { EnvKey = key }()
+	os.Setenv(EnvKey, "stage")
+	defer os.Unsetenv(EnvKey)
+	fmt.Printf("test: LoadEnvironment(%v) -> [err:%v] [env:%v]\n", EnvKey, LoadEnvironment(nil), EnvStr())
+
+	//Output:
+	//test: LoadEnvironment(nil) -> [err:<nil>] [env:stage]
+	//test: LoadEnvironment(RUNTIME_ENV) -> [err:<nil>] [env:test]
+	//test: LoadEnvironment([-port=8080 --env stage]) -> [err:<nil>] [env:stage]
+	//test: LoadEnvironment([-env=prod]) -> [err:<nil>] [env:prod]
+	//test: LoadEnvironment(APP_ENV) -> [err:<nil>] [env:stage]
+
+}
+
+func ExampleEnvValues() {
+	defer SetEnvironment(EnvStr())
+	timeout := EnvValues[int]{TestEnv: 60, DefaultEnvValue: 5}
+	url := EnvValues[string]{ProdEnv: "https://api.example.com"}
+
+	SetEnvironment(TestEnv)
+	fmt.Printf("test: Value(%v) -> [timeout:%v] [url:%v]\n", EnvStr(), timeout.Value(), url.Value())
+	SetEnvironment(ProdEnv)
+	fmt.Printf("test: Value(%v) -> [timeout:%v] [url:%v]\n", EnvStr(), timeout.Value(), url.Value())
+
+	//Output:
+	//test: Value(test) -> [timeout:60] [url:]
+	//test: Value(prod) -> [timeout:5] [url:https://api.example.com]
+
+}
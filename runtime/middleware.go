@@ -0,0 +1,79 @@
+package runtime
+
+import (
+	"context"
+	"net/http"
+)
+
+// Handler - constraint for the handler types supported by a middleware chain
+type Handler interface {
+	DoHandler | PostHandler | HttpHandler
+}
+
+// Middleware - function wrapping a handler, existing wrappers such as RecoverDo[E] can be converted directly:
+// Middleware[DoHandler](RecoverDo[LogError])
+type Middleware[H Handler] func(next H) H
+
+// Chain - an ordered list of middlewares, the first middleware is the outermost, and is called first
+type Chain[H Handler] struct {
+	middlewares []Middleware[H]
+}
+
+// NewChain - create a chain from middlewares, in calling order
+func NewChain[H Handler](m ...Middleware[H]) Chain[H] {
+	return Chain[H]{}.Append(m...)
+}
+
+// Append - create a new chain with middlewares added after the existing ones, nil middlewares are ignored
+func (c Chain[H]) Append(m ...Middleware[H]) Chain[H] {
+	t := make([]Middleware[H], 0, len(c.middlewares)+len(m))
+	t = append(t, c.middlewares...)
+	for _, fn := range m {
+		if fn != nil {
+			t = append(t, fn)
+		}
+	}
+	return Chain[H]{middlewares: t}
+}
+
+// Extend - create a new chain with the middlewares of another chain added after the existing ones
+func (c Chain[H]) Extend(d Chain[H]) Chain[H] {
+	return c.Append(d.middlewares...)
+}
+
+// Len - number of middlewares
+func (c Chain[H]) Len() int {
+	return len(c.middlewares)
+}
+
+// Then - wrap a handler with the chain
+func (c Chain[H]) Then(h H) H {
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		h = c.middlewares[i](h)
+	}
+	return h
+}
+
+// HandlerContext - the context for a handler, as a context.Context. A DoHandler or PostHandler ctx that is not a
+// context.Context falls back to the request context.
+func HandlerContext(ctx any, r *http.Request) context.Context {
+	if c, ok := ctx.(context.Context); ok && c != nil {
+		return c
+	}
+	if r != nil {
+		return r.Context()
+	}
+	return context.Background()
+}
+
+// WithState - create a new handler context with per-request middleware state, the context is passed on to the
+// next handler
+func WithState(ctx any, r *http.Request, key any, val any) context.Context {
+	return ContextWithValue(HandlerContext(ctx, r), key, val)
+}
+
+// State - templated function returning per-request middleware state from a handler context
+func State[T any](ctx any, r *http.Request, key any) (T, bool) {
+	t, ok := HandlerContext(ctx, r).Value(key).(T)
+	return t, ok
+}
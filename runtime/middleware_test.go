@@ -0,0 +1,57 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+)
+
+type userKey struct{}
+
+func trace(name string, calls *[]string) Middleware[DoHandler] {
+	return func(next DoHandler) DoHandler {
+		return func(ctx any, r *http.Request, body any) (any, *Status) {
+			*calls = append(*calls, name)
+			return next(ctx, r, body)
+		}
+	}
+}
+
+func ExampleChain_Then() {
+	var calls []string
+	auth := func(next DoHandler) DoHandler {
+		return func(ctx any, r *http.Request, body any) (any, *Status) {
+			calls = append(calls, "auth")
+			return next(WithState(ctx, r, userKey{}, "bob"), r, body)
+		}
+	}
+	c := NewChain[DoHandler](trace("log", &calls), auth).Append(trace("limit", &calls), nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://www.google.com/search?q=golang", nil)
+	t, status := c.Then(func(ctx any, r *http.Request, body any) (any, *Status) {
+		user, ok := State[string](ctx, r, userKey{})
+		return fmt.Sprintf("%v:%v", user, ok), NewStatusOK()
+	})(nil, req, nil)
+	fmt.Printf("test: Then() -> [t:%v] [status:%v] [calls:%v] [len:%v]\n", t, status, calls, c.Len())
+
+	//Output:
+	//test: Then() -> [t:bob:true] [status:OK] [calls:[log auth limit]] [len:3]
+
+}
+
+func ExampleChain_Then_http() {
+	c := NewChain[HttpHandler](Middleware[HttpHandler](RecoverHttp[BypassError]))
+	status := c.Then(func(ctx context.Context, w http.ResponseWriter, r *http.Request) *Status {
+		panic("chain failure")
+	})(context.Background(), httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/search", nil))
+	fmt.Printf("test: Then() -> [status:%v] [panic:%v]\n", status.Code(), IsPanicStatus(status))
+
+	user, ok := State[string](nil, nil, userKey{})
+	fmt.Printf("test: State() -> [user:%v] [ok:%v]\n", user, ok)
+
+	//Output:
+	//test: Then() -> [status:500] [panic:true]
+	//test: State() -> [user:] [ok:false]
+
+}
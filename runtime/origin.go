@@ -1,6 +1,12 @@
 package runtime
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
 
 const (
 	OriginRegionKey     = "ORIGIN-REGION"
@@ -10,7 +16,10 @@ const (
 	OriginInstanceIdKey = "ORIGIN-INSTANCE-ID"
 )
 
-var origin Origin
+var (
+	origin           Origin
+	originContextKey = &contextKey{"origin"}
+)
 
 // Origin - struct for origin information
 type Origin struct {
@@ -24,6 +33,7 @@ type Origin struct {
 func init() {
 	origin.Region = "region"
 	origin.Zone = "zone"
+	LoadOrigin()
 }
 
 func SetOrigin(region, zone, subZone, service, instanceId string) {
@@ -34,6 +44,109 @@ func SetOrigin(region, zone, subZone, service, instanceId string) {
 	origin.InstanceId = instanceId
 }
 
+// LoadOrigin - set the origin from the environment variables named by the origin keys, a "-" in a key may also be
+// "_". Variables that are not set keep the current value.
+func LoadOrigin() {
+	LoadOriginMap(originEnvMap())
+}
+
+func originEnvMap() map[string]string {
+	m := make(map[string]string)
+	for _, key := range originKeys() {
+		if v, ok := os.LookupEnv(key); ok {
+			m[key] = v
+		} else if v, ok = os.LookupEnv(strings.ReplaceAll(key, "-", "_")); ok {
+			m[key] = v
+		}
+	}
+	return m
+}
+
+// LoadOriginMap - set the origin from a configuration map keyed by the origin keys, keys that are missing or empty
+// keep the current value
+func LoadOriginMap(m map[string]string) {
+	set := func(field *string, key string) {
+		if v := m[key]; v != "" {
+			*field = v
+		}
+	}
+	set(&origin.Region, OriginRegionKey)
+	set(&origin.Zone, OriginZoneKey)
+	set(&origin.SubZone, OriginSubZoneKey)
+	set(&origin.Service, OriginServiceKey)
+	set(&origin.InstanceId, OriginInstanceIdKey)
+}
+
+func originKeys() []string {
+	return []string{OriginRegionKey, OriginZoneKey, OriginSubZoneKey, OriginServiceKey, OriginInstanceIdKey}
+}
+
+// GetOrigin - the origin
+func GetOrigin() Origin {
+	return origin
+}
+
+// AddOriginHeaders - add the origin as headers, empty values are not added
+func AddOriginHeaders(h http.Header) {
+	if h == nil {
+		return
+	}
+	for key, v := range origin.values() {
+		if v != "" {
+			h.Set(key, v)
+		}
+	}
+}
+
+// OriginFromHeader - create an origin from headers
+func OriginFromHeader(h http.Header) Origin {
+	if h == nil {
+		return Origin{}
+	}
+	return Origin{
+		Region:     h.Get(OriginRegionKey),
+		Zone:       h.Get(OriginZoneKey),
+		SubZone:    h.Get(OriginSubZoneKey),
+		Service:    h.Get(OriginServiceKey),
+		InstanceId: h.Get(OriginInstanceIdKey),
+	}
+}
+
+func (o Origin) values() map[string]string {
+	return map[string]string{OriginRegionKey: o.Region, OriginZoneKey: o.Zone, OriginSubZoneKey: o.SubZone, OriginServiceKey: o.Service, OriginInstanceIdKey: o.InstanceId}
+}
+
+// IsEmpty - determine if all origin values are empty
+func (o Origin) IsEmpty() bool {
+	return o == Origin{}
+}
+
+// NewOriginContext - creates a new Context with an origin
+func NewOriginContext(ctx context.Context, o Origin) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return ContextWithValue(ctx, originContextKey, o)
+}
+
+// NewIngressOriginContext - creates a new Context with the caller origin from the request headers
+func NewIngressOriginContext(req *http.Request) context.Context {
+	if req == nil {
+		return context.Background()
+	}
+	return NewOriginContext(req.Context(), OriginFromHeader(req.Header))
+}
+
+// OriginFromContext - return the origin from a context
+func OriginFromContext(ctx any) (Origin, bool) {
+	if ctx2, ok := ctx.(context.Context); ok && ctx2 != nil {
+		if o, ok2 := ctx2.Value(originContextKey).(Origin); ok2 {
+			return o, true
+		}
+	}
+	return Origin{}, false
+}
+
 func OriginRegion() string {
 	return origin.Region
 }
@@ -1,6 +1,10 @@
 package runtime
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+	"os"
+)
 
 func ExampleOriginUrn_Query() {
 	urn := OriginUrn("postgresql", "insert", "query-test-resource.prod")
@@ -11,3 +15,46 @@ func ExampleOriginUrn_Query() {
 	//test: OriginUrn() -> urn:postgresql.region.zone:insert.query-test-resource.prod
 
 }
+
+func ExampleLoadOrigin() {
+	prev := GetOrigin()
+	defer func() { origin = prev }()
+
+	os.Setenv("ORIGIN_REGION", "us-west")
+	os.Setenv(OriginZoneKey, "oregon")
+	defer os.Unsetenv("ORIGIN_REGION")
+	defer os.Unsetenv(OriginZoneKey)
+	LoadOrigin()
+	fmt.Printf("test: LoadOrigin() -> %v\n", OriginString())
+
+	LoadOriginMap(map[string]string{OriginSubZoneKey: "dc1", OriginServiceKey: "search", OriginInstanceIdKey: "123"})
+	fmt.Printf("test: LoadOriginMap() -> %v\n", OriginString())
+
+	//Output:
+	//test: LoadOrigin() -> us-west:oregon:::
+	//test: LoadOriginMap() -> us-west:oregon:dc1:search:123
+
+}
+
+func ExampleNewIngressOriginContext() {
+	prev := GetOrigin()
+	defer func() { origin = prev }()
+	SetOrigin("us-west", "oregon", "", "search", "")
+
+	req, _ := http.NewRequest(http.MethodGet, "https://www.google.com/search?q=golang", nil)
+	AddOriginHeaders(req.Header)
+	fmt.Printf("test: AddOriginHeaders() -> [region:%v] [zone:%v] [sub-zone:%v] [service:%v]\n", req.Header.Get("Origin-Region"), req.Header.Get(OriginZoneKey), req.Header.Values(OriginSubZoneKey), req.Header.Get(OriginServiceKey))
+
+	o, ok := OriginFromContext(req.Context())
+	fmt.Printf("test: OriginFromContext(req) -> [ok:%v] [empty:%v]\n", ok, o.IsEmpty())
+
+	ctx := NewIngressOriginContext(req)
+	o, ok = OriginFromContext(ctx)
+	fmt.Printf("test: OriginFromContext(ingress) -> [ok:%v] [origin:%v]\n", ok, o)
+
+	//Output:
+	//test: AddOriginHeaders() -> [region:us-west] [zone:oregon] [sub-zone:[]] [service:search]
+	//test: OriginFromContext(req) -> [ok:false] [empty:true]
+	//test: OriginFromContext(ingress) -> [ok:true] [origin:{us-west oregon  search }]
+
+}
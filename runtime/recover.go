@@ -0,0 +1,173 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/felixge/httpsnoop"
+	"io"
+	"net/http"
+	goruntime "runtime"
+	"strings"
+)
+
+const (
+	PanicFlag      = "PA" // access log status flag for a recovered panic
+	maxPanicFrames = 8
+)
+
+// PanicError - error for a recovered panic value
+type PanicError struct {
+	Value any
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// NewPanicStatus - create a status from a recovered panic value, the errors are a PanicError and a trimmed
+// stack, starting at the panic. Must be called from the deferred function that recovered.
+func NewPanicStatus(r any, location string) *Status {
+	errs := []error{&PanicError{Value: r}}
+	return NewStatusError(http.StatusInternalServerError, location, append(errs, panicStack()...)...)
+}
+
+// IsPanicStatus - determine if a status was created from a recovered panic
+func IsPanicStatus(s *Status) bool {
+	if s == nil {
+		return false
+	}
+	var e *PanicError
+	return errors.As(s.FirstError(), &e)
+}
+
+func panicStack() []error {
+	var errs []error
+
+	pc := make([]uintptr, 64)
+	n := goruntime.Callers(1, pc)
+	frames := goruntime.CallersFrames(pc[:n])
+	found := false
+	for {
+		f, more := frames.Next()
+		if found && !strings.HasPrefix(f.Function, "runtime.") {
+			errs = append(errs, errors.New(fmt.Sprintf("at %v (%v:%v)", f.Function, trimFile(f.File), f.Line)))
+			if len(errs) == maxPanicFrames {
+				break
+			}
+		}
+		if f.Function == "runtime.gopanic" {
+			found = true
+		}
+		if !more {
+			break
+		}
+	}
+	return errs
+}
+
+func trimFile(file string) string {
+	t := strings.Split(file, "/")
+	if len(t) > 2 {
+		return strings.Join(t[len(t)-2:], "/")
+	}
+	return file
+}
+
+var recoverLocation = PkgUri + "/Recover"
+
+// newRecoverStatus - must be called from the deferred function that recovered
+func newRecoverStatus(p any, r *http.Request) *Status {
+	return NewPanicStatus(p, recoverLocation).SetRequestId(requestIdOf(r))
+}
+
+func requestIdOf(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	return RequestId(r)
+}
+
+// RecoverDo - templated function wrapping a DoHandler, converting a panic into a status handled by the error handler
+func RecoverDo[E ErrorHandler](handler DoHandler) DoHandler {
+	return func(ctx any, r *http.Request, body any) (t any, status *Status) {
+		defer func() {
+			if p := recover(); p != nil {
+				var e E
+				t = nil
+				status = e.Handle(newRecoverStatus(p, r), requestIdOf(r), "")
+			}
+		}()
+		return handler(ctx, r, body)
+	}
+}
+
+// RecoverPost - templated function wrapping a PostHandler, converting a panic into a status handled by the error handler
+func RecoverPost[E ErrorHandler](handler PostHandler) PostHandler {
+	return func(ctx any, r *http.Request, body any) (t any, status *Status) {
+		defer func() {
+			if p := recover(); p != nil {
+				var e E
+				t = nil
+				status = e.Handle(newRecoverStatus(p, r), requestIdOf(r), "")
+			}
+		}()
+		return handler(ctx, r, body)
+	}
+}
+
+// RecoverHttp - templated function wrapping a HttpHandler, converting a panic into a status handled by the error handler
+func RecoverHttp[E ErrorHandler](handler HttpHandler) HttpHandler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) (status *Status) {
+		defer func() {
+			if p := recover(); p != nil {
+				var e E
+				status = e.Handle(newRecoverStatus(p, r), requestIdOf(r), "")
+			}
+		}()
+		return handler(ctx, w, r)
+	}
+}
+
+// RecoverHandler - templated function wrapping a http.Handler, converting a panic into a status handled by the
+// error handler, and an internal server error response if the handler has not written a response. The
+// http.ErrAbortHandler panic is not recovered.
+func RecoverHandler[E ErrorHandler](handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var wrote = false
+
+		w2 := httpsnoop.Wrap(w, httpsnoop.Hooks{
+			WriteHeader: func(next httpsnoop.WriteHeaderFunc) httpsnoop.WriteHeaderFunc {
+				return func(code int) {
+					wrote = true
+					next(code)
+				}
+			},
+			Write: func(next httpsnoop.WriteFunc) httpsnoop.WriteFunc {
+				return func(b []byte) (int, error) {
+					wrote = true
+					return next(b)
+				}
+			},
+			ReadFrom: func(next httpsnoop.ReadFromFunc) httpsnoop.ReadFromFunc {
+				return func(src io.Reader) (int64, error) {
+					wrote = true
+					return next(src)
+				}
+			},
+		})
+		defer func() {
+			if p := recover(); p != nil {
+				if p == http.ErrAbortHandler {
+					panic(p)
+				}
+				var e E
+				e.Handle(newRecoverStatus(p, r), requestIdOf(r), "")
+				if !wrote {
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			}
+		}()
+		handler.ServeHTTP(w2, r)
+	})
+}
@@ -0,0 +1,75 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+func panicDo(ctx any, r *http.Request, body any) (any, *Status) {
+	var m map[string]string
+	m["key"] = "value"
+	return nil, NewStatusOK()
+}
+
+func ExampleRecoverDo() {
+	req, _ := http.NewRequest(http.MethodGet, "https://www.google.com/search?q=golang", nil)
+	req.Header.Set(XRequestId, "123-456")
+
+	t, status := RecoverDo[BypassError](panicDo)(nil, req, nil)
+	fmt.Printf("test: RecoverDo() -> [t:%v] [status:%v] [panic:%v] [request-id:%v]\n", t, status.Code(), IsPanicStatus(status), status.RequestId())
+	fmt.Printf("test: RecoverDo() -> [error:%v]\n", status.FirstError())
+	fmt.Printf("test: RecoverDo() -> [at-panic:%v] [frames:%v]\n", strings.Contains(status.Errors()[1].Error(), "runtime.panicDo"), len(status.Errors()) <= maxPanicFrames+1)
+
+	t, status = RecoverDo[BypassError](func(ctx any, r *http.Request, body any) (any, *Status) { return "ok", NewStatusOK() })(nil, req, nil)
+	fmt.Printf("test: RecoverDo() -> [t:%v] [status:%v] [panic:%v]\n", t, status, IsPanicStatus(status))
+
+	//Output:
+	//test: RecoverDo() -> [t:<nil>] [status:500] [panic:true] [request-id:123-456]
+	//test: RecoverDo() -> [error:panic: assignment to entry in nil map]
+	//test: RecoverDo() -> [at-panic:true] [frames:true]
+	//test: RecoverDo() -> [t:ok] [status:OK] [panic:false]
+
+}
+
+func ExampleRecoverHandler() {
+	h := RecoverHandler[BypassError](http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("handler failure")
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/search", nil))
+	fmt.Printf("test: RecoverHandler() -> [code:%v]\n", rec.Code)
+
+	h = RecoverHandler[BypassError](http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("partial"))
+		panic("handler failure")
+	}))
+	rec2 := &headerRecorder{ResponseRecorder: httptest.NewRecorder()}
+	h.ServeHTTP(rec2, httptest.NewRequest(http.MethodGet, "/search", nil))
+	fmt.Printf("test: RecoverHandler(written) -> [code:%v] [write-header:%v] [body:%v]\n", rec2.Code, rec2.count, rec2.Body.String())
+
+	status := RecoverHttp[BypassError](func(ctx context.Context, w http.ResponseWriter, r *http.Request) *Status {
+		panic(fmt.Errorf("http failure"))
+	})(nil, rec, nil)
+	fmt.Printf("test: RecoverHttp() -> [code:%v] [error:%v]\n", status.Code(), status.FirstError())
+
+	//Output:
+	//test: RecoverHandler() -> [code:500]
+	//test: RecoverHandler(written) -> [code:200] [write-header:1] [body:partial]
+	//test: RecoverHttp() -> [code:500] [error:panic: http failure]
+
+}
+
+// headerRecorder - recorder counting the calls to WriteHeader
+type headerRecorder struct {
+	*httptest.ResponseRecorder
+	count int
+}
+
+func (r *headerRecorder) WriteHeader(code int) {
+	r.count++
+	r.ResponseRecorder.WriteHeader(code)
+}
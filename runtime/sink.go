@@ -0,0 +1,234 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRingCapacity = 256
+)
+
+// Sink - output for a status with errors
+type Sink interface {
+	Write(s *Status)
+}
+
+// StatusRecord - structured view of a status, the trace is ordered from the caller to the error location
+type StatusRecord struct {
+	Time      time.Time `json:"time"`
+	Code      int       `json:"code"`
+	Status    string    `json:"status"`
+	RequestId string    `json:"request-id,omitempty"`
+	Trace     []string  `json:"trace,omitempty"`
+	Errors    []string  `json:"errors,omitempty"`
+}
+
+// NewStatusRecord - create a record from a status
+func NewStatusRecord(s *Status) StatusRecord {
+	r := StatusRecord{Time: time.Now().UTC(), Code: s.Code(), Status: s.Description(), RequestId: s.RequestId()}
+	loc := s.Location()
+	for i := len(loc) - 1; i >= 0; i-- {
+		if loc[i] != "" {
+			r.Trace = append(r.Trace, loc[i])
+		}
+	}
+	for _, e := range s.Errors() {
+		if e != nil {
+			r.Errors = append(r.Errors, e.Error())
+		}
+	}
+	return r
+}
+
+// StatusAttrs - slog attributes for a status
+func StatusAttrs(s *Status) []slog.Attr {
+	r := NewStatusRecord(s)
+	attrs := []slog.Attr{slog.Int(StatusCodeName, r.Code), slog.String(StatusName, r.Status)}
+	if r.RequestId != "" {
+		attrs = append(attrs, slog.String(RequestIdName, r.RequestId))
+	}
+	if len(r.Trace) > 0 {
+		attrs = append(attrs, slog.Any(TraceName, r.Trace))
+	}
+	if len(r.Errors) > 0 {
+		attrs = append(attrs, slog.Any(ErrorsName, r.Errors))
+	}
+	return attrs
+}
+
+// SlogSink - sink writing slog records, at the error level
+type SlogSink struct {
+	logger *slog.Logger
+}
+
+// NewSlogSink - create a slog sink, a nil logger uses the default logger
+func NewSlogSink(logger *slog.Logger) *SlogSink {
+	return &SlogSink{logger: logger}
+}
+
+func (k *SlogSink) Write(s *Status) {
+	logger := k.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.LogAttrs(context.Background(), slog.LevelError, s.Description(), StatusAttrs(s)...)
+}
+
+// ChannelSink - sink sending statuses to a channel, statuses are dropped if the channel is full
+type ChannelSink chan *Status
+
+func (k ChannelSink) Write(s *Status) {
+	select {
+	case k <- s:
+	default:
+	}
+}
+
+// RingSink - sink keeping the most recent status records in memory, and serving them as JSON for a debug endpoint
+type RingSink struct {
+	buf  []StatusRecord
+	next int
+	full bool
+	mu   sync.Mutex
+}
+
+// NewRingSink - create a ring sink
+func NewRingSink(capacity int) *RingSink {
+	if capacity <= 0 {
+		capacity = DefaultRingCapacity
+	}
+	return &RingSink{buf: make([]StatusRecord, capacity)}
+}
+
+func (k *RingSink) Write(s *Status) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.buf[k.next] = NewStatusRecord(s)
+	k.next = (k.next + 1) % len(k.buf)
+	if k.next == 0 {
+		k.full = true
+	}
+}
+
+// All - the records, oldest first
+func (k *RingSink) All() []StatusRecord {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if !k.full {
+		return append([]StatusRecord(nil), k.buf[:k.next]...)
+	}
+	return append(append([]StatusRecord(nil), k.buf[k.next:]...), k.buf[:k.next]...)
+}
+
+func (k *RingSink) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	buf, err := json.Marshal(k.All())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(contentType, contentTypeJson)
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf)
+}
+
+// JsonLinesSink - sink writing a JSON status record per line
+type JsonLinesSink struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+// NewJsonLinesSink - create a JSON lines sink for a writer
+func NewJsonLinesSink(w io.Writer) *JsonLinesSink {
+	return &JsonLinesSink{w: w}
+}
+
+// NewJsonLinesFileSink - create a JSON lines sink appending to a file
+func NewJsonLinesFileSink(name string) (*JsonLinesSink, error) {
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return NewJsonLinesSink(f), nil
+}
+
+func (k *JsonLinesSink) Write(s *Status) {
+	buf, err := json.Marshal(NewStatusRecord(s))
+	if err != nil {
+		return
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.w.Write(append(buf, '\n'))
+}
+
+// Close - close the writer, if it is a closer
+func (k *JsonLinesSink) Close() error {
+	if c, ok := k.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+var (
+	sinks   []Sink
+	sinksMu sync.RWMutex
+)
+
+// SetSinks - set the sinks for SinkError
+func SetSinks(s ...Sink) {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	sinks = s
+}
+
+// SetEnvSinks - set the sinks for SinkError for the current environment
+func SetEnvSinks(v EnvValues[[]Sink]) {
+	SetSinks(v.Value()...)
+}
+
+func getSinks() []Sink {
+	sinksMu.RLock()
+	defer sinksMu.RUnlock()
+	return sinks
+}
+
+// SinkError - error handler writing to all configured sinks
+type SinkError struct{}
+
+func (h SinkError) Handle(s *Status, requestId string, callerLocation string) *Status {
+	if s == nil {
+		return s
+	}
+	s.SetRequestId(requestId)
+	s.AddLocation(callerLocation)
+	if s.IsErrors() && !s.ErrorsHandled() {
+		for _, k := range getSinks() {
+			k.Write(s)
+		}
+		s.SetErrorsHandled()
+	}
+	return s
+}
+
+// SlogError - error handler writing slog records to the default logger
+type SlogError struct{}
+
+func (h SlogError) Handle(s *Status, requestId string, callerLocation string) *Status {
+	if s == nil {
+		return s
+	}
+	s.SetRequestId(requestId)
+	s.AddLocation(callerLocation)
+	if s.IsErrors() && !s.ErrorsHandled() {
+		NewSlogSink(nil).Write(s)
+		s.SetErrorsHandled()
+	}
+	return s
+}
@@ -0,0 +1,66 @@
+package runtime
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+func newSinkStatus() *Status {
+	return NewStatusError(http.StatusServiceUnavailable, "/database", errors.New("connection refused"))
+}
+
+func ExampleSlogSink() {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return a
+	}}))
+	NewSlogSink(logger).Write(newSinkStatus().SetRequestId("123-456").AddLocation("/query"))
+	fmt.Printf("test: Write() -> %v", buf.String())
+
+	//Output:
+	//test: Write() -> {"level":"ERROR","msg":"Service Unavailable","code":503,"status":"Service Unavailable","request-id":"123-456","trace":["/query","/database"],"errors":["connection refused"]}
+
+}
+
+func ExampleSinkError() {
+	var h SinkError
+	var buf bytes.Buffer
+	c := make(ChannelSink, 1)
+	ring := NewRingSink(2)
+	defer SetEnvironment(EnvStr())
+	SetEnvironment(TestEnv)
+	SetEnvSinks(EnvValues[[]Sink]{TestEnv: {c, ring, NewJsonLinesSink(&buf)}, DefaultEnvValue: {NewSlogSink(nil)}})
+	defer SetSinks()
+
+	for i := 0; i < 3; i++ {
+		h.Handle(newSinkStatus(), fmt.Sprintf("id-%v", i), "/query")
+	}
+	s := h.Handle(NewStatusOK(), "", "")
+	fmt.Printf("test: Handle() -> [status:%v] [channel:%v] [ring:%v] [lines:%v]\n", s, len(c), len(ring.All()), strings.Count(buf.String(), "\n"))
+
+	msg := <-c
+	fmt.Printf("test: ChannelSink() -> [request-id:%v]\n", msg.RequestId())
+	for _, r := range ring.All() {
+		fmt.Printf("test: RingSink() -> [code:%v] [request-id:%v] [trace:%v] [errors:%v]\n", r.Code, r.RequestId, r.Trace, r.Errors)
+	}
+
+	rec := httptest.NewRecorder()
+	ring.ServeHTTP(rec, nil)
+	fmt.Printf("test: ServeHTTP() -> [code:%v] [content-type:%v]\n", rec.Code, rec.Header().Get(contentType))
+
+	//Output:
+	//test: Handle() -> [status:OK] [channel:1] [ring:2] [lines:3]
+	//test: ChannelSink() -> [request-id:id-0]
+	//test: RingSink() -> [code:503] [request-id:id-1] [trace:[/query /database]] [errors:[connection refused]]
+	//test: RingSink() -> [code:503] [request-id:id-2] [trace:[/query /database]] [errors:[connection refused]]
+	//test: ServeHTTP() -> [code:200] [content-type:application/json]
+
+}
@@ -34,8 +34,8 @@ func ExampleMessageCache_Add() {
 
 	//Output:
 	//test: count() -> : 5
-	//test: Get(invalid) -> : [error:invalid argument: uri not found [invalid]] [msg:{   <nil> [] <nil>}]
-	//test: Get(from-uri-3) -> : [error:<nil>] [msg:{to-uri from-uri-3 event:ping Not provided [] <nil>}]
+	//test: Get(invalid) -> : [error:invalid argument: uri not found [invalid]] [msg:{    <nil> [] <nil>}]
+	//test: Get(from-uri-3) -> : [error:<nil>] [msg:{to-uri from-uri-3 event:ping  Not provided [] <nil>}]
 	//test: include(event:shutdown,Code(94)) -> : []
 	//test: exclude(event:shutdown,Code(94)) -> : [from-uri-0 from-uri-1 from-uri-2 from-uri-3 from-uri-4]
 	//test: include(event:startup,Code(94)) -> : [from-uri-0]
@@ -73,8 +73,8 @@ func ExampleMessageCache_Uri() {
 
 	//Output:
 	//test: count() -> : 5
-	//test: Get(invalid) -> : [error:invalid argument: uri not found [invalid]] [msg:{   <nil> [] <nil>}]
-	//test: Get(from-uri-3) -> : [error:<nil>] [msg:{to-uri from-uri-3 event:ping Not provided [] <nil>}]
+	//test: Get(invalid) -> : [error:invalid argument: uri not found [invalid]] [msg:{    <nil> [] <nil>}]
+	//test: Get(from-uri-3) -> : [error:<nil>] [msg:{to-uri from-uri-3 event:ping  Not provided [] <nil>}]
 	//test: include(event:shutdown,Code(94)) -> : []
 	//test: exclude(event:shutdown,Code(94)) -> : [from-uri-0 from-uri-1 from-uri-2 from-uri-3 from-uri-4]
 	//test: include(event:startup,Code(94)) -> : [from-uri-0]
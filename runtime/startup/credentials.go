@@ -0,0 +1,204 @@
+package startup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	strings2 "github.com/go-ai-agent/core/strings"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	UsernameKey = "username"
+	PasswordKey = "password"
+
+	DefaultCredentialsInterval = time.Second * 30
+)
+
+// EnvCredentials - credentials provider reading the username and password from environment variables
+func EnvCredentials(usernameKey, passwordKey string) Credentials {
+	return func() (string, string, error) {
+		username, ok := os.LookupEnv(usernameKey)
+		if !ok {
+			return "", "", errors.New(fmt.Sprintf("credentials not found: environment variable is not set [%v]", usernameKey))
+		}
+		password, ok := os.LookupEnv(passwordKey)
+		if !ok {
+			return "", "", errors.New(fmt.Sprintf("credentials not found: environment variable is not set [%v]", passwordKey))
+		}
+		return username, password, nil
+	}
+}
+
+// FileCredentials - credentials provider reading from a file, or from a Kubernetes style mounted secret directory.
+// A file contains "username: value" and "password: value" lines, a directory contains files named username and
+// password. The file is read on every call, use a CredentialsCache to avoid the reads.
+func FileCredentials(name string) Credentials {
+	return func() (string, string, error) {
+		return readCredentials(name)
+	}
+}
+
+func readCredentials(name string) (string, string, error) {
+	fi, err := os.Stat(name)
+	if err != nil {
+		return "", "", err
+	}
+	if fi.IsDir() {
+		username, err0 := os.ReadFile(filepath.Join(name, UsernameKey))
+		if err0 != nil {
+			return "", "", err0
+		}
+		password, err1 := os.ReadFile(filepath.Join(name, PasswordKey))
+		if err1 != nil {
+			return "", "", err1
+		}
+		return strings.TrimSpace(string(username)), strings.TrimSpace(string(password)), nil
+	}
+	buf, err2 := os.ReadFile(name)
+	if err2 != nil {
+		return "", "", err2
+	}
+	m, err3 := strings2.TextToMap(buf)
+	if errs := strings2.ValidateMap(m, err3, UsernameKey, PasswordKey); len(errs) > 0 {
+		return "", "", errors.New(fmt.Sprintf("credentials file is invalid: [%v] %v", name, errs))
+	}
+	return m[UsernameKey], m[PasswordKey], nil
+}
+
+// ChainCredentials - credentials provider returning the result of the first provider that does not error
+func ChainCredentials(providers ...Credentials) Credentials {
+	return func() (string, string, error) {
+		var errs []string
+		for _, fn := range providers {
+			if fn == nil {
+				continue
+			}
+			username, password, err := fn()
+			if err == nil {
+				return username, password, nil
+			}
+			errs = append(errs, err.Error())
+		}
+		if len(errs) == 0 {
+			return "", "", errors.New("credentials chain failed: no providers")
+		}
+		return "", "", errors.New(fmt.Sprintf("credentials chain failed: %v", strings.Join(errs, "; ")))
+	}
+}
+
+// CredentialsCache - caching credentials provider. When started, the provider is polled and a rotation is
+// published to the subscribers of the cache host, as a CredentialsRotatedEvent message from the cache uri, with
+// the Credentials as content.
+type CredentialsCache struct {
+	host     *Host
+	uri      string
+	fn       Credentials
+	interval time.Duration
+	username string
+	password string
+	loaded   bool
+	stop     chan struct{}
+	mu       sync.RWMutex
+}
+
+// NewCredentialsCache - create a cache for a credentials provider, the interval is the rotation polling interval.
+// Rotations are published with the default host.
+func NewCredentialsCache(uri string, fn Credentials, interval time.Duration) *CredentialsCache {
+	return defaultHost.NewCredentialsCache(uri, fn, interval)
+}
+
+// NewFileCredentialsCache - create a cache for file credentials, rotations are published with the default host
+func NewFileCredentialsCache(uri, name string, interval time.Duration) *CredentialsCache {
+	return defaultHost.NewFileCredentialsCache(uri, name, interval)
+}
+
+// NewCredentialsCache - create a cache for a credentials provider, rotations are published with the host
+func (h *Host) NewCredentialsCache(uri string, fn Credentials, interval time.Duration) *CredentialsCache {
+	if interval <= 0 {
+		interval = DefaultCredentialsInterval
+	}
+	return &CredentialsCache{host: h, uri: uri, fn: fn, interval: interval}
+}
+
+// NewFileCredentialsCache - create a cache for file credentials, rotations are published with the host
+func (h *Host) NewFileCredentialsCache(uri, name string, interval time.Duration) *CredentialsCache {
+	return h.NewCredentialsCache(uri, FileCredentials(name), interval)
+}
+
+func (c *CredentialsCache) Uri() string { return c.uri }
+
+// Credentials - the cached credentials, the provider is only called if no credentials have been loaded
+func (c *CredentialsCache) Credentials() (string, string, error) {
+	c.mu.RLock()
+	if c.loaded {
+		defer c.mu.RUnlock()
+		return c.username, c.password, nil
+	}
+	c.mu.RUnlock()
+	if _, err := c.Refresh(); err != nil {
+		return "", "", err
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.username, c.password, nil
+}
+
+// Refresh - call the provider and update the cache, returning true if previously loaded credentials have changed.
+// On a provider error the cached credentials are retained.
+func (c *CredentialsCache) Refresh() (bool, error) {
+	if c.fn == nil {
+		return false, errors.New(fmt.Sprintf("invalid argument: credentials provider is nil [%v]", c.uri))
+	}
+	username, password, err := c.fn()
+	if err != nil {
+		return false, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rotated := c.loaded && (username != c.username || password != c.password)
+	c.username = username
+	c.password = password
+	c.loaded = true
+	return rotated, nil
+}
+
+// Start - poll the provider and publish rotations, a started cache is not restarted
+func (c *CredentialsCache) Start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stop != nil {
+		return
+	}
+	c.stop = make(chan struct{})
+	go c.run(c.stop)
+}
+
+// Stop - stop polling the provider
+func (c *CredentialsCache) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stop != nil {
+		close(c.stop)
+		c.stop = nil
+	}
+}
+
+func (c *CredentialsCache) run(stop chan struct{}) {
+	tick := time.NewTicker(c.interval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+			if rotated, err := c.Refresh(); err == nil && rotated {
+				c.host.Publish(context.Background(), Message{From: c.uri, Event: CredentialsRotatedEvent, Content: []any{Credentials(c.Credentials)}})
+			}
+		case <-stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package startup
+
+import (
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+func ExampleEnvCredentials() {
+	os.Setenv("CREDENTIALS_TEST_USER", "bob")
+	os.Setenv("CREDENTIALS_TEST_PASSWORD", "secret")
+	defer os.Unsetenv("CREDENTIALS_TEST_USER")
+	defer os.Unsetenv("CREDENTIALS_TEST_PASSWORD")
+
+	username, password, err := EnvCredentials("CREDENTIALS_TEST_USER", "CREDENTIALS_TEST_PASSWORD")()
+	fmt.Printf("test: EnvCredentials() -> [username:%v] [password:%v] [err:%v]\n", username, password, err)
+
+	_, _, err = EnvCredentials("CREDENTIALS_TEST_INVALID", "CREDENTIALS_TEST_PASSWORD")()
+	fmt.Printf("test: EnvCredentials(invalid) -> [err:%v]\n", err)
+
+	//Output:
+	//test: EnvCredentials() -> [username:bob] [password:secret] [err:<nil>]
+	//test: EnvCredentials(invalid) -> [err:credentials not found: environment variable is not set [CREDENTIALS_TEST_INVALID]]
+
+}
+
+func ExampleFileCredentials() {
+	dir, _ := os.MkdirTemp("", "credentials")
+	defer os.RemoveAll(dir)
+
+	name := filepath.Join(dir, "credentials.txt")
+	os.WriteFile(name, []byte("username: bob\npassword: secret\n"), 0600)
+	username, password, err := FileCredentials(name)()
+	fmt.Printf("test: FileCredentials(file) -> [username:%v] [password:%v] [err:%v]\n", username, password, err)
+
+	secret := filepath.Join(dir, "secret")
+	os.Mkdir(secret, 0700)
+	os.WriteFile(filepath.Join(secret, UsernameKey), []byte("alice\n"), 0600)
+	os.WriteFile(filepath.Join(secret, PasswordKey), []byte("s3cret\n"), 0600)
+	username, password, err = FileCredentials(secret)()
+	fmt.Printf("test: FileCredentials(dir) -> [username:%v] [password:%v] [err:%v]\n", username, password, err)
+
+	os.WriteFile(name, []byte("username: bob\n"), 0600)
+	_, _, err = FileCredentials(name)()
+	fmt.Printf("test: FileCredentials(invalid) -> [err:%v]\n", err != nil)
+
+	//Output:
+	//test: FileCredentials(file) -> [username:bob] [password:secret] [err:<nil>]
+	//test: FileCredentials(dir) -> [username:alice] [password:s3cret] [err:<nil>]
+	//test: FileCredentials(invalid) -> [err:true]
+
+}
+
+func ExampleChainCredentials() {
+	username, password, err := ChainCredentials(EnvCredentials("CREDENTIALS_TEST_INVALID", "CREDENTIALS_TEST_INVALID"),
+		func() (string, string, error) { return "bob", "let-me-in", nil })()
+	fmt.Printf("test: ChainCredentials() -> [username:%v] [password:%v] [err:%v]\n", username, password, err)
+
+	_, _, err = ChainCredentials(EnvCredentials("CREDENTIALS_TEST_INVALID", "CREDENTIALS_TEST_INVALID"))()
+	fmt.Printf("test: ChainCredentials(invalid) -> [err:%v]\n", err)
+
+	_, _, err = ChainCredentials()()
+	fmt.Printf("test: ChainCredentials(empty) -> [err:%v]\n", err)
+
+	//Output:
+	//test: ChainCredentials() -> [username:bob] [password:let-me-in] [err:<nil>]
+	//test: ChainCredentials(invalid) -> [err:credentials chain failed: credentials not found: environment variable is not set [CREDENTIALS_TEST_INVALID]]
+	//test: ChainCredentials(empty) -> [err:credentials chain failed: no providers]
+
+}
+
+func ExampleCredentialsCache() {
+	uri := "urn:credentials:test"
+	dir, _ := os.MkdirTemp("", "credentials")
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "credentials.txt")
+	os.WriteFile(name, []byte("username: bob\npassword: secret\n"), 0600)
+
+	c := make(chan Message, 16)
+	Subscribe(Subscription{Uri: "urn:credentials:subscriber", Pattern: CredentialsRotatedEvent, C: c})
+	defer Unsubscribe("urn:credentials:subscriber", "")
+
+	cache := NewFileCredentialsCache(uri, name, time.Millisecond*20)
+	username, password, err := cache.Credentials()
+	fmt.Printf("test: Credentials() -> [username:%v] [password:%v] [err:%v]\n", username, password, err)
+
+	cache.Start()
+	defer cache.Stop()
+	os.WriteFile(name, []byte("username: bob\npassword: rotated\n"), 0600)
+
+	select {
+	case msg := <-c:
+		username, password, err = AccessCredentials(&msg)()
+		fmt.Printf("test: Rotated() -> [from:%v] [event:%v] [username:%v] [password:%v] [err:%v]\n", msg.From, msg.Event, username, password, err)
+	case <-time.After(time.Second):
+		fmt.Printf("test: Rotated() -> [timeout]\n")
+	}
+
+	os.Remove(name)
+	time.Sleep(time.Millisecond * 50)
+	username, password, err = cache.Credentials()
+	fmt.Printf("test: Credentials(removed) -> [username:%v] [password:%v] [err:%v]\n", username, password, err)
+
+	//Output:
+	//test: Credentials() -> [username:bob] [password:secret] [err:<nil>]
+	//test: Rotated() -> [from:urn:credentials:test] [event:event:credentials-rotated] [username:bob] [password:rotated] [err:<nil>]
+	//test: Credentials(removed) -> [username:bob] [password:rotated] [err:<nil>]
+
+}
+
+func ExampleHost_NewCredentialsCache() {
+	uri := "urn:credentials:host"
+	h := NewHost[runtime.BypassError]("urn:credentials:host")
+	var username atomic.Value
+	username.Store("bob")
+	fn := func() (string, string, error) { return username.Load().(string), "secret", nil }
+
+	c := make(chan Message, 16)
+	h.Subscribe(Subscription{Uri: "urn:credentials:subscriber", Pattern: CredentialsRotatedEvent, C: c})
+	d := make(chan Message, 16)
+	Subscribe(Subscription{Uri: "urn:credentials:default", Pattern: CredentialsRotatedEvent, C: d})
+	defer Unsubscribe("urn:credentials:default", "")
+
+	cache := h.NewCredentialsCache(uri, fn, time.Millisecond*20)
+	cache.Credentials()
+	cache.Start()
+	defer cache.Stop()
+	username.Store("alice")
+
+	select {
+	case msg := <-c:
+		username, _, _ := AccessCredentials(&msg)()
+		fmt.Printf("test: Rotated() -> [from:%v] [username:%v] [default-host:%v]\n", msg.From, username, len(d))
+	case <-time.After(time.Second):
+		fmt.Printf("test: Rotated() -> [timeout]\n")
+	}
+
+	//Output:
+	//test: Rotated() -> [from:urn:credentials:host] [username:alice] [default-host:0]
+
+}
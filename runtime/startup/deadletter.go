@@ -0,0 +1,73 @@
+package startup
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	DefaultDeadLetterCapacity = 256
+)
+
+// DeadLetter - a message that could not be delivered, and the reason
+type DeadLetter struct {
+	Msg       Message
+	Err       error
+	Timestamp time.Time
+}
+
+// DeadLetterStore - bounded store of dead letters, the oldest dead letters are discarded when full
+type DeadLetterStore struct {
+	list     []DeadLetter
+	capacity int
+	mu       sync.RWMutex
+}
+
+// NewDeadLetterStore - create a dead letter store
+func NewDeadLetterStore(capacity int) *DeadLetterStore {
+	if capacity <= 0 {
+		capacity = DefaultDeadLetterCapacity
+	}
+	return &DeadLetterStore{capacity: capacity}
+}
+
+func (s *DeadLetterStore) Add(msg Message, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.list) >= s.capacity {
+		s.list = s.list[1:]
+	}
+	s.list = append(s.list, DeadLetter{Msg: msg, Err: err, Timestamp: time.Now().UTC()})
+}
+
+func (s *DeadLetterStore) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.list)
+}
+
+// All - all dead letters, oldest first
+func (s *DeadLetterStore) All() []DeadLetter {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]DeadLetter(nil), s.list...)
+}
+
+// Filter - dead letters addressed to a uri, oldest first
+func (s *DeadLetterStore) Filter(uri string) []DeadLetter {
+	var list []DeadLetter
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, d := range s.list {
+		if d.Msg.To == uri {
+			list = append(list, d)
+		}
+	}
+	return list
+}
+
+func (s *DeadLetterStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.list = nil
+}
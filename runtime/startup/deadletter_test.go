@@ -0,0 +1,31 @@
+package startup
+
+import (
+	"errors"
+	"fmt"
+)
+
+func ExampleDeadLetterStore_Add() {
+	s := NewDeadLetterStore(2)
+
+	s.Add(Message{To: "urn:one", Event: StartupEvent}, errors.New("entry not found: [urn:one]"))
+	s.Add(Message{To: "urn:two", Event: StartupEvent}, errors.New("entry channel is full: [urn:two]"))
+	s.Add(Message{To: "urn:two", Event: PingEvent}, errors.New("entry channel is full: [urn:two]"))
+	fmt.Printf("test: Count() -> : %v\n", s.Count())
+
+	for _, d := range s.All() {
+		fmt.Printf("test: All() -> : [to:%v] [event:%v] [err:%v]\n", d.Msg.To, d.Msg.Event, d.Err)
+	}
+	fmt.Printf("test: Filter(urn:one) -> : %v\n", len(s.Filter("urn:one")))
+
+	s.Clear()
+	fmt.Printf("test: Clear() -> : %v\n", s.Count())
+
+	//Output:
+	//test: Count() -> : 2
+	//test: All() -> : [to:urn:two] [event:event:startup] [err:entry channel is full: [urn:two]]
+	//test: All() -> : [to:urn:two] [event:event:ping] [err:entry channel is full: [urn:two]]
+	//test: Filter(urn:one) -> : 0
+	//test: Clear() -> : 0
+
+}
@@ -1,27 +1,119 @@
 package startup
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sort"
 	"sync"
+	"sync/atomic"
 )
 
-// Entry - and entry in an EntryDirectory
+const (
+	EntryAddedEvent   = "event:entry-added"
+	EntryRemovedEvent = "event:entry-removed"
+)
+
+// EntryEvent - a change to an EntryDirectory
+type EntryEvent struct {
+	Event string
+	Uri   string
+}
+
+// Entry - and entry in an EntryDirectory, the entry channel is closed by the directory, and must not be closed by
+// its owner
 type Entry struct {
-	uri string
-	c   chan Message
+	uri       string
+	c         chan Message
+	done      chan struct{}
+	once      sync.Once
+	mu        sync.RWMutex
+	closed    bool
+	started   int32
+	delivered int64
+	dropped   int64
+}
+
+func newEntry(uri string, c chan Message) *Entry {
+	return &Entry{uri: uri, c: c, done: make(chan struct{})}
+}
+
+func (e *Entry) Uri() string { return e.uri }
+
+// Depth - number of messages queued in the entry channel
+func (e *Entry) Depth() int { return len(e.c) }
+
+// Delivered - number of messages delivered to the entry channel
+func (e *Entry) Delivered() int { return int(atomic.LoadInt64(&e.delivered)) }
+
+// Dropped - number of messages that could not be delivered to the entry channel
+func (e *Entry) Dropped() int { return int(atomic.LoadInt64(&e.dropped)) }
+
+// Started - determine if the entry has been started by Run
+func (e *Entry) Started() bool { return atomic.LoadInt32(&e.started) == 1 }
+func (e *Entry) setStarted()   { atomic.StoreInt32(&e.started, 1) }
+
+func (e *Entry) String() string {
+	return fmt.Sprintf("{uri:%v depth:%v delivered:%v dropped:%v}", e.uri, e.Depth(), e.Delivered(), e.Dropped())
+}
+
+// send - send a message, a nil context will not wait on a full channel. The read lock is held for the send, so
+// the channel cannot be closed until the send completes.
+func (e *Entry) send(ctx context.Context, msg Message) error {
+	if e.c == nil {
+		return errors.New(fmt.Sprintf("entry channel is nil: [%v]", e.uri))
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closed {
+		return errors.New(fmt.Sprintf("entry channel is closed: [%v]", e.uri))
+	}
+	if ctx == nil {
+		select {
+		case e.c <- msg:
+			return nil
+		default:
+			return errors.New(fmt.Sprintf("entry channel is full: [%v]", e.uri))
+		}
+	}
+	select {
+	case e.c <- msg:
+		return nil
+	case <-e.done:
+		return errors.New(fmt.Sprintf("entry channel is closed: [%v]", e.uri))
+	case <-ctx.Done():
+		return errors.New(fmt.Sprintf("entry channel is full: [%v] [%v]", e.uri, ctx.Err()))
+	}
+}
+
+// close - close the entry channel, releasing any senders waiting on a full channel
+func (e *Entry) close() {
+	// Closing done before taking the lock releases senders holding the read lock
+	e.once.Do(func() { close(e.done) })
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return
+	}
+	e.closed = true
+	if e.c != nil {
+		close(e.c)
+	}
 }
 
 // EntryDirectory - collection of Entry
 type EntryDirectory struct {
-	m  map[string]*Entry
-	mu sync.RWMutex
+	m        map[string]*Entry
+	closed   map[chan Message]struct{}
+	dead     *DeadLetterStore
+	watchers map[int]chan EntryEvent
+	next     int
+	mu       sync.RWMutex
 }
 
 // NewEntryDirectory - create a new directory
 func NewEntryDirectory() *EntryDirectory {
-	return &EntryDirectory{m: make(map[string]*Entry)}
+	return &EntryDirectory{m: make(map[string]*Entry), closed: make(map[chan Message]struct{}), dead: NewDeadLetterStore(DefaultDeadLetterCapacity), watchers: make(map[int]chan EntryEvent)}
 }
 
 func (d *EntryDirectory) Get(uri string) *Entry {
@@ -30,12 +122,74 @@ func (d *EntryDirectory) Get(uri string) *Entry {
 	return d.m[uri]
 }
 
+// Add - add an entry, an existing entry for the uri is replaced, and if the channel differs the existing
+// entry channel is closed
 func (d *EntryDirectory) Add(uri string, c chan Message) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	d.m[uri] = &Entry{
-		uri: uri,
-		c:   c,
+	if prev, ok := d.m[uri]; ok {
+		if prev.c != c {
+			d.closeEntry(prev)
+		}
+		d.notify(EntryEvent{Event: EntryRemovedEvent, Uri: uri})
+	}
+	e := newEntry(uri, c)
+	// A channel closed by the directory cannot be sent on, so the entry starts closed
+	if _, ok := d.closed[c]; ok {
+		e.closed = true
+		e.once.Do(func() { close(e.done) })
+	}
+	d.m[uri] = e
+	d.notify(EntryEvent{Event: EntryAddedEvent, Uri: uri})
+}
+
+// Remove - remove an entry and close the entry channel
+func (d *EntryDirectory) Remove(uri string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	e, ok := d.m[uri]
+	if !ok {
+		return false
+	}
+	d.closeEntry(e)
+	delete(d.m, uri)
+	d.notify(EntryEvent{Event: EntryRemovedEvent, Uri: uri})
+	return true
+}
+
+// closeEntry - close an entry, recording the closed channel, callers must hold the directory lock
+func (d *EntryDirectory) closeEntry(e *Entry) {
+	e.close()
+	if e.c != nil {
+		d.closed[e.c] = struct{}{}
+	}
+}
+
+// Watch - receive added and removed events, events are dropped if the channel is full. The returned function
+// stops the watch.
+func (d *EntryDirectory) Watch(c chan EntryEvent) func() {
+	if c == nil {
+		return func() {}
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	id := d.next
+	d.next++
+	d.watchers[id] = c
+	return func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		delete(d.watchers, id)
+	}
+}
+
+// notify - send an event to all watchers, callers must hold the directory lock
+func (d *EntryDirectory) notify(event EntryEvent) {
+	for _, c := range d.watchers {
+		select {
+		case c <- event:
+		default:
+		}
 	}
 }
 
@@ -56,36 +210,74 @@ func (d *EntryDirectory) Uri() []string {
 	return uri
 }
 
+// DeadLetters - messages that could not be delivered
+func (d *EntryDirectory) DeadLetters() *DeadLetterStore {
+	return d.dead
+}
+
+// Send - send a message, waiting on a full entry channel until the message is delivered or the entry is closed
 func (d *EntryDirectory) Send(msg Message) error {
-	d.mu.RLock()
-	defer d.mu.RUnlock()
-	if e, ok := d.m[msg.To]; ok {
-		if e.c == nil {
-			return errors.New(fmt.Sprintf("entry channel is nil: [%v]", msg.To))
-		}
-		e.c <- msg
-		return nil
+	return d.send(context.Background(), msg)
+}
+
+// TrySend - send a message without waiting, an error is returned if the entry is not found, or the entry channel
+// is full
+func (d *EntryDirectory) TrySend(msg Message) error {
+	return d.send(nil, msg)
+}
+
+// SendContext - send a message, waiting on a full entry channel until the context is done
+func (d *EntryDirectory) SendContext(ctx context.Context, msg Message) error {
+	if ctx == nil {
+		ctx = context.Background()
 	}
-	return errors.New(fmt.Sprintf("entry not found: [%v]", msg.To))
+	return d.send(ctx, msg)
 }
 
+func (d *EntryDirectory) send(ctx context.Context, msg Message) error {
+	e := d.Get(msg.To)
+	if e == nil {
+		err := errors.New(fmt.Sprintf("entry not found: [%v]", msg.To))
+		d.dead.Add(msg, err)
+		return err
+	}
+	err := e.send(ctx, msg)
+	if err != nil {
+		atomic.AddInt64(&e.dropped, 1)
+		d.dead.Add(msg, err)
+		return err
+	}
+	atomic.AddInt64(&e.delivered, 1)
+	return nil
+}
+
+// Shutdown - send a shutdown message to all entries, waiting a maximum of 2 seconds on full entry channels
 func (d *EntryDirectory) Shutdown() {
-	d.mu.RLock()
-	defer d.mu.RUnlock()
-	for _, e := range d.m {
-		if e.c != nil {
-			e.c <- Message{To: e.uri, Event: ShutdownEvent}
+	d.shutdown(d.Uri())
+}
+
+func (d *EntryDirectory) shutdown(uri []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), maxWait)
+	defer cancel()
+	var wg sync.WaitGroup
+	for _, u := range uri {
+		if e := d.Get(u); e != nil && e.c != nil {
+			wg.Add(1)
+			go func(u string) {
+				defer wg.Done()
+				d.SendContext(ctx, Message{To: u, Event: ShutdownEvent})
+			}(u)
 		}
 	}
+	wg.Wait()
 }
 
 func (d *EntryDirectory) Empty() {
-	d.mu.RLock()
-	defer d.mu.RUnlock()
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	for key, e := range d.m {
-		if e.c != nil {
-			close(e.c)
-		}
+		d.closeEntry(e)
 		delete(d.m, key)
+		d.notify(EntryEvent{Event: EntryRemovedEvent, Uri: key})
 	}
 }
@@ -1,6 +1,7 @@
 package startup
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -43,10 +44,10 @@ func ExampleEntryDirectory_Add() {
 	//test: get(urn:test) -> : <nil>
 	//test: add(urn:test) -> : ok
 	//test: count() -> : 1
-	//test: get(urn:test) -> : &{urn:test <nil>}
+	//test: get(urn:test) -> : {uri:urn:test depth:0 delivered:0 dropped:0}
 	//test: add(urn:test:two) -> : ok
 	//test: count() -> : 2
-	//test: get(urn:test:two) -> : &{urn:test:two <nil>}
+	//test: get(urn:test:two) -> : {uri:urn:test:two depth:0 delivered:0 dropped:0}
 	//test: uri() -> : [urn:test urn:test:two]
 
 }
@@ -94,3 +95,93 @@ func ExampleEntryDirectory_Send() {
 	//test: <- c -> : [urn:test-1] [urn:test-2] [urn:test-3]
 
 }
+
+func ExampleEntryDirectory_Send_full() {
+	uri := "urn:test:full"
+	dir := NewEntryDirectory()
+	c := make(chan Message, 1)
+	dir.Add(uri, c)
+
+	fmt.Printf("test: TrySend(%v) -> : %v\n", uri, dir.TrySend(Message{To: uri, Event: StartupEvent}))
+	fmt.Printf("test: TrySend(%v) -> : %v\n", uri, dir.TrySend(Message{To: uri, Event: PingEvent}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	fmt.Printf("test: SendContext(%v) -> : %v\n", uri, dir.SendContext(ctx, Message{To: uri, Event: StatusEvent}))
+	fmt.Printf("test: Get(%v) -> : %v\n", uri, dir.Get(uri))
+
+	go func() {
+		time.Sleep(time.Millisecond * 100)
+		<-c
+	}()
+	fmt.Printf("test: Send(%v) -> : %v\n", uri, dir.Send(Message{To: uri, Event: ShutdownEvent}))
+	fmt.Printf("test: Get(%v) -> : %v\n", uri, dir.Get(uri))
+
+	//Output:
+	//test: TrySend(urn:test:full) -> : <nil>
+	//test: TrySend(urn:test:full) -> : entry channel is full: [urn:test:full]
+	//test: SendContext(urn:test:full) -> : entry channel is full: [urn:test:full] [context deadline exceeded]
+	//test: Get(urn:test:full) -> : {uri:urn:test:full depth:1 delivered:1 dropped:2}
+	//test: Send(urn:test:full) -> : <nil>
+	//test: Get(urn:test:full) -> : {uri:urn:test:full depth:1 delivered:2 dropped:2}
+
+}
+
+func ExampleEntryDirectory_DeadLetters() {
+	uri := "urn:test:dead"
+	dir := NewEntryDirectory()
+	c := make(chan Message, 1)
+	dir.Add(uri, c)
+
+	dir.Send(Message{To: "urn:test:unknown", Event: StartupEvent})
+	dir.Send(Message{To: uri, Event: StartupEvent})
+	dir.TrySend(Message{To: uri, Event: PingEvent})
+	dir.Empty()
+	dir.Add(uri, c)
+	dir.Send(Message{To: uri, Event: ShutdownEvent})
+
+	fmt.Printf("test: DeadLetters().Count() -> : %v\n", dir.DeadLetters().Count())
+	for _, d := range dir.DeadLetters().Filter(uri) {
+		fmt.Printf("test: DeadLetters().Filter(%v) -> : [event:%v] [err:%v]\n", uri, d.Msg.Event, d.Err)
+	}
+
+	//Output:
+	//test: DeadLetters().Count() -> : 3
+	//test: DeadLetters().Filter(urn:test:dead) -> : [event:event:ping] [err:entry channel is full: [urn:test:dead]]
+	//test: DeadLetters().Filter(urn:test:dead) -> : [event:event:shutdown] [err:entry channel is closed: [urn:test:dead]]
+
+}
+
+func ExampleEntryDirectory_Watch() {
+	uri := "urn:test:watch"
+	dir := NewEntryDirectory()
+	events := make(chan EntryEvent, 16)
+	cancel := dir.Watch(events)
+
+	c := make(chan Message, 1)
+	dir.Add(uri, c)
+	c2 := make(chan Message, 1)
+	dir.Add(uri, c2)
+	_, open := <-c
+	fmt.Printf("test: Add(%v) -> : [previous-channel-open:%v]\n", uri, open)
+
+	fmt.Printf("test: Remove(%v) -> : %v\n", uri, dir.Remove(uri))
+	fmt.Printf("test: Remove(%v) -> : %v\n", uri, dir.Remove(uri))
+	cancel()
+	dir.Add(uri, nil)
+
+	close(events)
+	for e := range events {
+		fmt.Printf("test: Watch() -> : [event:%v] [uri:%v]\n", e.Event, e.Uri)
+	}
+
+	//Output:
+	//test: Add(urn:test:watch) -> : [previous-channel-open:false]
+	//test: Remove(urn:test:watch) -> : true
+	//test: Remove(urn:test:watch) -> : false
+	//test: Watch() -> : [event:event:entry-added] [uri:urn:test:watch]
+	//test: Watch() -> : [event:event:entry-removed] [uri:urn:test:watch]
+	//test: Watch() -> : [event:event:entry-added] [uri:urn:test:watch]
+	//test: Watch() -> : [event:event:entry-removed] [uri:urn:test:watch]
+
+}
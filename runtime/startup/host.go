@@ -0,0 +1,101 @@
+package startup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"sync"
+)
+
+// Host - startup host, owning a directory of registered resources, and the broker, pending requests and
+// startup report for those resources. Hosts are independent, so several can coexist in one process. The
+// package functions use a default host named HostName.
+type Host struct {
+	name     string
+	handler  runtime.ErrorHandler
+	dir      *EntryDirectory
+	broker   *Broker
+	replies  *replyTable
+	deps     map[string][]string
+	depsMu   sync.RWMutex
+	report   *Report
+	reportMu sync.RWMutex
+}
+
+// NewHost - templated function to create a host, the error handler is used by Run and Ping. The name is the
+// from uri of messages sent by the host.
+func NewHost[E runtime.ErrorHandler](name string) *Host {
+	var e E
+	if name == "" {
+		name = HostName
+	}
+	h := &Host{name: name, handler: e, dir: NewEntryDirectory(), replies: newReplyTable(), deps: make(map[string][]string)}
+	h.broker = NewDirectoryBroker(h.dir)
+	return h
+}
+
+var defaultHost = NewHost[runtime.LogError](HostName)
+
+func (h *Host) Name() string { return h.name }
+
+// Directory - the directory of registered resources
+func (h *Host) Directory() *EntryDirectory { return h.dir }
+
+// Broker - the publish/subscribe broker, subscriptions without a channel are delivered to the registered uri
+func (h *Host) Broker() *Broker { return h.broker }
+
+// Register - register a startup uri
+func (h *Host) Register(uri string, c chan Message) error {
+	if uri == "" {
+		return errors.New("invalid argument: uri is empty")
+	}
+	if c == nil {
+		return errors.New(fmt.Sprintf("invalid argument: channel is nil for [%v]", uri))
+	}
+	h.dir.Add(uri, c)
+	return nil
+}
+
+// Unregister - unregister a startup uri, the registered channel is closed and any subscriptions are removed
+func (h *Host) Unregister(uri string) error {
+	if uri == "" {
+		return errors.New("invalid argument: uri is empty")
+	}
+	if !h.dir.Remove(uri) {
+		return errors.New(fmt.Sprintf("invalid argument: uri is not registered [%v]", uri))
+	}
+	h.broker.Unsubscribe(uri, "")
+	return nil
+}
+
+// Watch - watch for registered and unregistered startup uris
+func (h *Host) Watch(c chan EntryEvent) (cancel func()) {
+	return h.dir.Watch(c)
+}
+
+// Shutdown - send a shutdown message to all registered startup uris
+func (h *Host) Shutdown() {
+	h.dir.Shutdown()
+}
+
+// DeadLetters - messages that could not be delivered to a startup uri
+func (h *Host) DeadLetters() *DeadLetterStore {
+	return h.dir.DeadLetters()
+}
+
+// Subscribe - add a subscription, if the subscription channel is nil, then messages are sent to the
+// registered startup uri
+func (h *Host) Subscribe(s Subscription) error {
+	return h.broker.Subscribe(s)
+}
+
+// Unsubscribe - remove a subscription
+func (h *Host) Unsubscribe(uri, pattern string) {
+	h.broker.Unsubscribe(uri, pattern)
+}
+
+// Publish - broadcast a message to all matching subscribers
+func (h *Host) Publish(ctx context.Context, msg Message) *runtime.Status {
+	return h.broker.Publish(ctx, msg)
+}
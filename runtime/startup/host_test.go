@@ -0,0 +1,48 @@
+package startup
+
+import (
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"time"
+)
+
+func ExampleNewHost() {
+	uri := "urn:host:resource"
+	h1 := NewHost[runtime.BypassError]("urn:host:one")
+	h2 := NewHost[runtime.BypassError]("urn:host:two")
+
+	c1 := make(chan Message, 16)
+	h1.Register(uri, c1)
+	go hostReply(c1, 0)
+
+	c2 := make(chan Message, 16)
+	h2.Register(uri, c2)
+	go hostReply(c2, time.Millisecond*10)
+
+	fmt.Printf("test: Register() -> [h1:%v] [h2:%v] [default:%v]\n", h1.Directory().Uri(), h2.Directory().Uri(), defaultHost.dir.Get(uri) != nil)
+
+	status := h1.Run(time.Second, nil)
+	fmt.Printf("test: Run(%v) -> [status:%v] [report:%v]\n", h1.Name(), status, len(h1.LastReport().Entries))
+	fmt.Printf("test: LastReport(%v) -> [report:%v]\n", h2.Name(), h2.LastReport() != nil)
+
+	status = h2.Ping(nil, uri)
+	fmt.Printf("test: Ping(%v) -> [status:%v]\n", h2.Name(), status)
+
+	fmt.Printf("test: Unregister(%v) -> [err:%v] [h1:%v] [h2:%v]\n", h2.Name(), h2.Unregister(uri), h1.Directory().Count(), h2.Directory().Count())
+	h1.Shutdown()
+
+	//Output:
+	//test: Register() -> [h1:[urn:host:resource]] [h2:[urn:host:resource]] [default:false]
+	//test: Run(urn:host:one) -> [status:OK] [report:1]
+	//test: LastReport(urn:host:two) -> [report:false]
+	//test: Ping(urn:host:two) -> [status:OK]
+	//test: Unregister(urn:host:two) -> [err:<nil>] [h1:1] [h2:0]
+
+}
+
+func hostReply(c chan Message, delay time.Duration) {
+	for msg := range c {
+		time.Sleep(delay)
+		ReplyTo(msg, runtime.NewStatusOK())
+	}
+}
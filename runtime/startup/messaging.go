@@ -10,6 +10,8 @@ const (
 	PingEvent     = "event:ping"
 	StatusEvent   = "event:status"
 	HostName      = "startup"
+
+	CredentialsRotatedEvent = "event:credentials-rotated"
 )
 
 // MessageHandler - function type to process a Message
@@ -17,12 +19,13 @@ type MessageHandler func(msg Message)
 
 // Message - message access data
 type Message struct {
-	To      string
-	From    string
-	Event   string
-	Status  *runtime.Status
-	Content []any
-	ReplyTo MessageHandler
+	To            string
+	From          string
+	Event         string
+	CorrelationId string
+	Status        *runtime.Status
+	Content       []any
+	ReplyTo       MessageHandler
 }
 
 // ReplyTo - function used by message recipient to reply with a runtime.Status
@@ -31,12 +34,13 @@ func ReplyTo(msg Message, status *runtime.Status) {
 		return
 	}
 	msg.ReplyTo(Message{
-		To:      msg.From,
-		From:    msg.To,
-		Event:   msg.Event,
-		Status:  status,
-		Content: nil,
-		ReplyTo: nil,
+		To:            msg.From,
+		From:          msg.To,
+		Event:         msg.Event,
+		CorrelationId: msg.CorrelationId,
+		Status:        status,
+		Content:       nil,
+		ReplyTo:       nil,
 	})
 }
 
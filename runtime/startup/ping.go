@@ -18,36 +18,30 @@ var pingLocation = PkgUri + "/Ping"
 // Ping - templated function to "ping" a startup
 func Ping[E runtime.ErrorHandler](ctx context.Context, uri string) (status *runtime.Status) {
 	var e E
+	return defaultHost.ping(e, ctx, uri)
+}
+
+// Ping - "ping" a startup uri, using the host error handler
+func (h *Host) Ping(ctx context.Context, uri string) *runtime.Status {
+	return h.ping(h.handler, ctx, uri)
+}
 
+func (h *Host) ping(e runtime.ErrorHandler, ctx context.Context, uri string) *runtime.Status {
 	if uri == "" {
 		//return e.Handle(runtime.RequestId(ctx), pingLocation, errors.New("invalid argument: startup uri is empty"))
 		return e.Handle(runtime.NewStatusError(http.StatusInternalServerError, pingLocation, errors.New("invalid argument: startup uri is empty")),
 			runtime.RequestId(ctx), "")
 
 	}
-	cache := NewMessageCache()
-	msg := Message{To: uri, From: HostName, Event: PingEvent, Status: nil, ReplyTo: NewMessageCacheHandler(cache)}
-	err := directory.Send(msg)
-	if err != nil {
-		//return e.Handle(runtime.RequestId(ctx), pingLocation, err)
-		return e.Handle(runtime.NewStatusError(http.StatusInternalServerError, pingLocation, err), runtime.RequestId(ctx), "")
-
+	reply, status := h.Request(ctx, uri, PingEvent, nil)
+	if reply.Status != nil {
+		return reply.Status
 	}
-	duration := maxWait
-	for wait := time.Duration(float64(duration) * 0.20); duration >= 0; duration -= wait {
-		time.Sleep(wait)
-		result, err1 := cache.Get(uri)
-		if err1 != nil {
-			continue //return e.HandleWithContext(ctx, pingLocation, err1)
-		}
-		if result.Status == nil {
-			//return e.Handle(runtime.RequestId(ctx), pingLocation, errors.New(fmt.Sprintf("ping response status not available: [%v]", uri))).SetCode(runtime.StatusNotProvided)
-			return e.Handle(runtime.NewStatusError(http.StatusInternalServerError, pingLocation, errors.New(fmt.Sprintf("ping response status not available: [%v]", uri))), runtime.RequestId(ctx), "")
-
-		}
-		return result.Status
+	switch status.Code() {
+	case runtime.StatusDeadlineExceeded:
+		return e.Handle(runtime.NewStatusError(runtime.StatusDeadlineExceeded, pingLocation, errors.New(fmt.Sprintf("ping response time out: [%v]", uri))), runtime.RequestId(ctx), "")
+	case runtime.StatusNotProvided:
+		return e.Handle(runtime.NewStatusError(http.StatusInternalServerError, pingLocation, errors.New(fmt.Sprintf("ping response status not available: [%v]", uri))), runtime.RequestId(ctx), "")
 	}
-	//return e.Handle(runtime.RequestId(ctx), pingLocation, errors.New(fmt.Sprintf("ping response time out: [%v]", uri))).SetCode(runtime.StatusDeadlineExceeded)
-	return e.Handle(runtime.NewStatusError(runtime.StatusDeadlineExceeded, pingLocation, errors.New(fmt.Sprintf("ping response time out: [%v]", uri))), runtime.RequestId(ctx), "")
-
+	return e.Handle(runtime.NewStatusError(http.StatusInternalServerError, pingLocation, status.Errors()...), runtime.RequestId(ctx), "")
 }
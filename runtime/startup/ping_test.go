@@ -15,7 +15,7 @@ func ExamplePing() {
 	uri4 := "urn:ping:delay"
 
 	start = time.Now()
-	directory.Empty()
+	defaultHost.dir.Empty()
 
 	c := make(chan Message, 16)
 	Register(uri1, c)
@@ -0,0 +1,269 @@
+package startup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"path"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy - policy applied when a subscriber buffer is full
+type OverflowPolicy int
+
+const (
+	DropNewest   OverflowPolicy = iota // discard the message being published
+	DropOldest                         // discard the oldest buffered message to make room
+	BackPressure                       // wait for room, bounded by the publish context
+)
+
+const (
+	DefaultSubscriberBuffer = 16
+)
+
+var publishLocation = PkgUri + "/Publish"
+
+// Subscription - subscriber configuration. The pattern is matched against a message event, and against the
+// message from uri, and supports path.Match wildcards, e.g. "event:config-changed" or "urn:cache:*"
+type Subscription struct {
+	Uri     string
+	Pattern string
+	C       chan Message
+	Buffer  int
+	Policy  OverflowPolicy
+}
+
+type subscriber struct {
+	sub       Subscription
+	dir       *EntryDirectory
+	queue     chan Message
+	ctx       context.Context
+	cancel    context.CancelFunc
+	delivered int64
+	dropped   int64
+}
+
+func newSubscriber(s Subscription, dir *EntryDirectory) *subscriber {
+	if s.Buffer <= 0 {
+		s.Buffer = DefaultSubscriberBuffer
+	}
+	sub := &subscriber{sub: s, dir: dir, queue: make(chan Message, s.Buffer)}
+	sub.ctx, sub.cancel = context.WithCancel(context.Background())
+	go sub.run()
+	return sub
+}
+
+func (s *subscriber) run() {
+	for {
+		select {
+		case msg := <-s.queue:
+			s.deliver(msg)
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *subscriber) deliver(msg Message) {
+	if s.sub.C == nil {
+		if s.dir.SendContext(s.ctx, msg) != nil {
+			atomic.AddInt64(&s.dropped, 1)
+			return
+		}
+		atomic.AddInt64(&s.delivered, 1)
+		return
+	}
+	select {
+	case s.sub.C <- msg:
+		atomic.AddInt64(&s.delivered, 1)
+	case <-s.ctx.Done():
+	}
+}
+
+func (s *subscriber) match(msg Message) bool {
+	if ok, _ := path.Match(s.sub.Pattern, msg.Event); ok {
+		return true
+	}
+	ok, _ := path.Match(s.sub.Pattern, msg.From)
+	return ok
+}
+
+func (s *subscriber) enqueue(ctx context.Context, msg Message) bool {
+	select {
+	case s.queue <- msg:
+		return true
+	default:
+	}
+	switch s.sub.Policy {
+	case DropOldest:
+		for {
+			select {
+			case <-s.queue:
+				atomic.AddInt64(&s.dropped, 1)
+			default:
+			}
+			select {
+			case s.queue <- msg:
+				return true
+			default:
+			}
+		}
+	case BackPressure:
+		select {
+		case s.queue <- msg:
+			return true
+		case <-ctx.Done():
+		case <-s.ctx.Done():
+		}
+	}
+	atomic.AddInt64(&s.dropped, 1)
+	return false
+}
+
+// Broker - topic based publish/subscribe of messages
+type Broker struct {
+	m   map[string]*subscriber
+	dir *EntryDirectory
+	mu  sync.RWMutex
+}
+
+// NewBroker - create a new broker, all subscriptions require a channel
+func NewBroker() *Broker {
+	return &Broker{m: make(map[string]*subscriber)}
+}
+
+// NewDirectoryBroker - create a new broker, subscriptions without a channel are delivered to the directory
+// entry of the subscriber uri
+func NewDirectoryBroker(dir *EntryDirectory) *Broker {
+	b := NewBroker()
+	b.dir = dir
+	return b
+}
+
+func subscriberKey(uri, pattern string) string {
+	return uri + " " + pattern
+}
+
+// Subscribe - add a subscription, replacing any existing subscription for the same uri and pattern
+func (b *Broker) Subscribe(s Subscription) error {
+	if s.Uri == "" {
+		return errors.New("invalid argument: subscriber uri is empty")
+	}
+	if _, err := path.Match(s.Pattern, ""); s.Pattern == "" || err != nil {
+		return errors.New(fmt.Sprintf("invalid argument: subscription pattern is invalid [%v] for [%v]", s.Pattern, s.Uri))
+	}
+	if s.C == nil && b.dir == nil {
+		return errors.New(fmt.Sprintf("invalid argument: channel is nil for [%v]", s.Uri))
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	key := subscriberKey(s.Uri, s.Pattern)
+	if prev, ok := b.m[key]; ok {
+		prev.cancel()
+	}
+	b.m[key] = newSubscriber(s, b.dir)
+	return nil
+}
+
+// Unsubscribe - remove a subscription, an empty pattern removes all subscriptions for the uri
+func (b *Broker) Unsubscribe(uri, pattern string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for key, s := range b.m {
+		if s.sub.Uri == uri && (pattern == "" || s.sub.Pattern == pattern) {
+			s.cancel()
+			delete(b.m, key)
+		}
+	}
+}
+
+// Count - number of subscriptions
+func (b *Broker) Count() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.m)
+}
+
+// Uri - sorted list of subscriber uris
+func (b *Broker) Uri() []string {
+	var uri []string
+	set := make(map[string]bool)
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, s := range b.m {
+		if !set[s.sub.Uri] {
+			set[s.sub.Uri] = true
+			uri = append(uri, s.sub.Uri)
+		}
+	}
+	sort.Strings(uri)
+	return uri
+}
+
+// Delivered - number of messages delivered to a subscription
+func (b *Broker) Delivered(uri, pattern string) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if s, ok := b.m[subscriberKey(uri, pattern)]; ok {
+		return int(atomic.LoadInt64(&s.delivered))
+	}
+	return 0
+}
+
+// Dropped - number of messages dropped for a subscription
+func (b *Broker) Dropped(uri, pattern string) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if s, ok := b.m[subscriberKey(uri, pattern)]; ok {
+		return int(atomic.LoadInt64(&s.dropped))
+	}
+	return 0
+}
+
+// Publish - broadcast a message to all matching subscribers. Only subscriptions with a BackPressure policy will
+// wait, and only until the context is done. A StatusRateLimited status is returned if any subscriber dropped the message.
+func (b *Broker) Publish(ctx context.Context, msg Message) *runtime.Status {
+	var errs []error
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	b.mu.RLock()
+	var subs []*subscriber
+	for _, s := range b.m {
+		if s.match(msg) {
+			subs = append(subs, s)
+		}
+	}
+	b.mu.RUnlock()
+	for _, s := range subs {
+		m := msg
+		m.To = s.sub.Uri
+		if !s.enqueue(ctx, m) {
+			errs = append(errs, errors.New(fmt.Sprintf("subscriber buffer full: [%v] [%v]", s.sub.Uri, msg.Event)))
+		}
+	}
+	if len(errs) > 0 {
+		return runtime.NewStatusError(runtime.StatusRateLimited, publishLocation, errs...)
+	}
+	return runtime.NewStatusOK()
+}
+
+// Subscribe - add a subscription to the default broker. If the subscription channel is nil, then messages are
+// sent to the registered startup uri.
+func Subscribe(s Subscription) error {
+	return defaultHost.Subscribe(s)
+}
+
+// Unsubscribe - remove a subscription from the default broker
+func Unsubscribe(uri, pattern string) {
+	defaultHost.Unsubscribe(uri, pattern)
+}
+
+// Publish - broadcast a message to all matching subscribers of the default broker
+func Publish(ctx context.Context, msg Message) *runtime.Status {
+	return defaultHost.Publish(ctx, msg)
+}
@@ -0,0 +1,106 @@
+package startup
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func ExampleBroker_Subscribe() {
+	b := NewBroker()
+	c := make(chan Message, 16)
+
+	fmt.Printf("test: Subscribe(empty-uri) -> %v\n", b.Subscribe(Subscription{Pattern: "event:*", C: c}))
+	fmt.Printf("test: Subscribe(empty-pattern) -> %v\n", b.Subscribe(Subscription{Uri: "urn:sub", C: c}))
+	fmt.Printf("test: Subscribe(nil-channel) -> %v\n", b.Subscribe(Subscription{Uri: "urn:sub", Pattern: "event:*"}))
+
+	b.Subscribe(Subscription{Uri: "urn:sub", Pattern: "event:*", C: c})
+	b.Subscribe(Subscription{Uri: "urn:sub", Pattern: "urn:cache:*", C: c})
+	b.Subscribe(Subscription{Uri: "urn:sub-2", Pattern: "event:config-changed", C: c})
+	fmt.Printf("test: Subscribe() -> [count:%v] [uri:%v]\n", b.Count(), b.Uri())
+
+	b.Unsubscribe("urn:sub", "")
+	fmt.Printf("test: Unsubscribe(urn:sub) -> [count:%v] [uri:%v]\n", b.Count(), b.Uri())
+
+	//Output:
+	//test: Subscribe(empty-uri) -> invalid argument: subscriber uri is empty
+	//test: Subscribe(empty-pattern) -> invalid argument: subscription pattern is invalid [] for [urn:sub]
+	//test: Subscribe(nil-channel) -> invalid argument: channel is nil for [urn:sub]
+	//test: Subscribe() -> [count:3] [uri:[urn:sub urn:sub-2]]
+	//test: Unsubscribe(urn:sub) -> [count:1] [uri:[urn:sub-2]]
+
+}
+
+func ExampleBroker_Publish() {
+	b := NewBroker()
+	config := make(chan Message, 16)
+	cache := make(chan Message, 16)
+
+	b.Subscribe(Subscription{Uri: "urn:config", Pattern: "event:config-changed", C: config})
+	b.Subscribe(Subscription{Uri: "urn:cache-watcher", Pattern: "urn:cache:*", C: cache})
+
+	status := b.Publish(nil, Message{From: "urn:agent", Event: "event:config-changed"})
+	msg := <-config
+	fmt.Printf("test: Publish(event:config-changed) -> [status:%v] [to:%v] [from:%v] [event:%v]\n", status, msg.To, msg.From, msg.Event)
+
+	status = b.Publish(nil, Message{From: "urn:cache:redis", Event: StatusEvent})
+	msg = <-cache
+	fmt.Printf("test: Publish(urn:cache:redis) -> [status:%v] [to:%v] [from:%v] [event:%v]\n", status, msg.To, msg.From, msg.Event)
+
+	status = b.Publish(nil, Message{From: "urn:other", Event: StatusEvent})
+	fmt.Printf("test: Publish(urn:other) -> [status:%v] [config:%v] [cache:%v]\n", status, len(config), len(cache))
+
+	//Output:
+	//test: Publish(event:config-changed) -> [status:OK] [to:urn:config] [from:urn:agent] [event:event:config-changed]
+	//test: Publish(urn:cache:redis) -> [status:OK] [to:urn:cache-watcher] [from:urn:cache:redis] [event:event:status]
+	//test: Publish(urn:other) -> [status:OK] [config:0] [cache:0]
+
+}
+
+func ExampleBroker_Publish_overflow() {
+	b := NewBroker()
+	// An unbuffered and unread channel, so the subscriber buffer fills
+	c := make(chan Message)
+
+	b.Subscribe(Subscription{Uri: "urn:drop-newest", Pattern: "event:*", C: c, Buffer: 1, Policy: DropNewest})
+	b.Publish(nil, Message{From: "urn:agent", Event: StatusEvent})
+	time.Sleep(time.Millisecond * 100)
+	for i := 0; i < 3; i++ {
+		b.Publish(nil, Message{From: "urn:agent", Event: StatusEvent})
+	}
+	status := b.Publish(nil, Message{From: "urn:agent", Event: StatusEvent})
+	fmt.Printf("test: Publish(DropNewest) -> [status:%v] [dropped:%v]\n", status, b.Dropped("urn:drop-newest", "event:*"))
+	b.Unsubscribe("urn:drop-newest", "")
+
+	b.Subscribe(Subscription{Uri: "urn:back-pressure", Pattern: "event:*", C: c, Buffer: 1, Policy: BackPressure})
+	b.Publish(nil, Message{From: "urn:agent", Event: StatusEvent})
+	time.Sleep(time.Millisecond * 100)
+	b.Publish(nil, Message{From: "urn:agent", Event: StatusEvent})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	status = b.Publish(ctx, Message{From: "urn:agent", Event: StatusEvent})
+	fmt.Printf("test: Publish(BackPressure) -> [status:%v] [dropped:%v]\n", status, b.Dropped("urn:back-pressure", "event:*"))
+	b.Unsubscribe("urn:back-pressure", "")
+
+	//Output:
+	//test: Publish(DropNewest) -> [status:Rate Limited [subscriber buffer full: [urn:drop-newest] [event:status]]] [dropped:3]
+	//test: Publish(BackPressure) -> [status:Rate Limited [subscriber buffer full: [urn:back-pressure] [event:status]]] [dropped:1]
+
+}
+
+func ExampleSubscribe() {
+	uri := "urn:pubsub:registered"
+	c := make(chan Message, 16)
+	defaultHost.dir.Empty()
+	Register(uri, c)
+
+	err := Subscribe(Subscription{Uri: uri, Pattern: "event:config-changed"})
+	status := Publish(nil, Message{From: "urn:agent", Event: "event:config-changed"})
+	msg := <-c
+	fmt.Printf("test: Subscribe() -> [err:%v] [status:%v] [to:%v] [event:%v]\n", err, status, msg.To, msg.Event)
+	Unsubscribe(uri, "")
+
+	//Output:
+	//test: Subscribe() -> [err:<nil>] [status:OK] [to:urn:pubsub:registered] [event:event:config-changed]
+
+}
@@ -0,0 +1,251 @@
+package startup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	strings2 "github.com/go-ai-agent/core/strings"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// ReportEntry - startup result for a resource. Order is the dependency order of the resource, starting at 1,
+// which is the order in which startup messages are sent. Sent is zero when the startup message was not delivered,
+// and Replied is zero when no reply was received.
+type ReportEntry struct {
+	Uri     string
+	Order   int
+	Status  *runtime.Status
+	Sent    time.Time
+	Replied time.Time
+}
+
+// Duration - time from sending the startup message to receiving the reply
+func (e ReportEntry) Duration() time.Duration {
+	if e.Sent.IsZero() || e.Replied.IsZero() {
+		return runtime.NilDuration
+	}
+	return e.Replied.Sub(e.Sent)
+}
+
+// Report - structured result of the calls to Run. The entries of each Run are merged, so the report has an
+// entry for every registered resource that was sent a startup message, and the status is that of the most
+// recent Run.
+type Report struct {
+	Env     string
+	Start   time.Time
+	End     time.Time
+	Status  *runtime.Status
+	Entries []ReportEntry
+}
+
+type reportEntryView struct {
+	Uri      string   `json:"uri"`
+	Order    int      `json:"order"`
+	Code     int      `json:"code"`
+	Status   string   `json:"status"`
+	Duration string   `json:"duration"`
+	Sent     string   `json:"sent"`
+	Replied  string   `json:"replied"`
+	Trace    []string `json:"trace"`
+	Errors   []string `json:"errors"`
+}
+
+type reportView struct {
+	Env      string            `json:"env"`
+	Start    string            `json:"start"`
+	End      string            `json:"end"`
+	Duration string            `json:"duration"`
+	Code     int               `json:"code"`
+	Status   string            `json:"status"`
+	Entries  []reportEntryView `json:"entries"`
+}
+
+func newStatusView(s *runtime.Status) (code int, desc string, trace, errs []string) {
+	if s == nil {
+		s = runtime.NewStatus(runtime.StatusNotProvided)
+	}
+	for _, l := range s.Location() {
+		if l != "" {
+			trace = append(trace, l)
+		}
+	}
+	for _, e := range s.Errors() {
+		errs = append(errs, e.Error())
+	}
+	return s.Code(), s.Description(), trace, errs
+}
+
+func fmtReportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return strings2.FmtTimestamp(t)
+}
+
+func fmtReportDuration(d time.Duration) string {
+	if d == runtime.NilDuration {
+		return ""
+	}
+	return d.String()
+}
+
+func (r *Report) view() reportView {
+	v := reportView{Env: r.Env, Start: fmtReportTime(r.Start), End: fmtReportTime(r.End), Duration: fmtReportDuration(r.End.Sub(r.Start))}
+	v.Code, v.Status, _, _ = newStatusView(r.Status)
+	for _, e := range r.Entries {
+		ev := reportEntryView{Uri: e.Uri, Order: e.Order, Duration: fmtReportDuration(e.Duration()), Sent: fmtReportTime(e.Sent), Replied: fmtReportTime(e.Replied)}
+		ev.Code, ev.Status, ev.Trace, ev.Errors = newStatusView(e.Status)
+		v.Entries = append(v.Entries, ev)
+	}
+	return v
+}
+
+// Json - render the report as JSON
+func (r *Report) Json() ([]byte, error) {
+	return json.Marshal(r.view())
+}
+
+// Text - render the report as a plain-text table
+func (r *Report) Text() string {
+	var buf bytes.Buffer
+	v := r.view()
+	fmt.Fprintf(&buf, "startup [%v] env [%v] start [%v] duration [%v]\n", v.Status, v.Env, v.Start, v.Duration)
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ORDER\tURI\tCODE\tSTATUS\tDURATION\tSENT\tREPLIED\tERRORS")
+	for _, e := range v.Entries {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", e.Order, e.Uri, e.Code, e.Status, e.Duration, e.Sent, e.Replied, strings.Join(e.Errors, "; "))
+	}
+	w.Flush()
+	lines := strings.Split(buf.String(), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// reportRecorder - message handler recording the send and reply times of startup messages
+type reportRecorder struct {
+	cache   *MessageCache
+	sent    map[string]time.Time
+	replied map[string]time.Time
+	mu      sync.Mutex
+}
+
+func newReportRecorder(cache *MessageCache) *reportRecorder {
+	return &reportRecorder{cache: cache, sent: make(map[string]time.Time), replied: make(map[string]time.Time)}
+}
+
+func (r *reportRecorder) handle(msg Message) {
+	r.mu.Lock()
+	if _, ok := r.replied[msg.From]; !ok && msg.From != "" {
+		r.replied[msg.From] = time.Now().UTC()
+	}
+	r.mu.Unlock()
+	r.cache.Add(msg)
+}
+
+func (r *reportRecorder) setSent(uri string, t time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent[uri] = t
+}
+
+func (r *reportRecorder) build(start time.Time, msgs messageMap, status *runtime.Status) *Report {
+	report := &Report{Env: runtime.EnvStr(), Start: start, End: time.Now().UTC(), Status: status}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, uri := range msgs.uri() {
+		e := ReportEntry{Uri: uri, Sent: r.sent[uri], Replied: r.replied[uri]}
+		if msg, err := r.cache.Get(uri); err == nil {
+			e.Status = msg.Status
+		}
+		report.Entries = append(report.Entries, e)
+	}
+	return report
+}
+
+// merge - merge the entries of a previous report that are not in the report, and are still registered, then
+// order the entries by dependency
+func (r *Report) merge(prev *Report, registered func(uri string) bool, order func(uri []string) []string) {
+	if prev != nil {
+		m := make(map[string]bool)
+		for _, e := range r.Entries {
+			m[e.Uri] = true
+		}
+		for _, e := range prev.Entries {
+			if !m[e.Uri] && registered(e.Uri) {
+				r.Entries = append(r.Entries, e)
+			}
+		}
+		if prev.Start.Before(r.Start) {
+			r.Start = prev.Start
+		}
+	}
+	index := make(map[string]int)
+	var uri []string
+	for _, e := range r.Entries {
+		uri = append(uri, e.Uri)
+	}
+	for i, u := range order(uri) {
+		index[u] = i + 1
+	}
+	for i := range r.Entries {
+		r.Entries[i].Order = index[r.Entries[i].Uri]
+	}
+	sort.Slice(r.Entries, func(i, j int) bool { return r.Entries[i].Order < r.Entries[j].Order })
+}
+
+// LastReport - report of the calls to Run, nil if Run has not been called
+func LastReport() *Report {
+	return defaultHost.LastReport()
+}
+
+// LastReport - report of the calls to Run for the host
+func (h *Host) LastReport() *Report {
+	h.reportMu.RLock()
+	defer h.reportMu.RUnlock()
+	return h.report
+}
+
+// mergeLastReport - merge a Run report with the previous report, the previous report is not modified
+func (h *Host) mergeLastReport(r *Report) {
+	h.reportMu.Lock()
+	defer h.reportMu.Unlock()
+	r.merge(h.report, func(uri string) bool { return h.dir.Get(uri) != nil }, h.dependencyOrder)
+	h.report = r
+}
+
+// ReportHandler - debug HTTP handler for the report of the calls to Run, rendered as JSON when
+// requested via the Accept header or a "format=json" query, otherwise as plain text
+func ReportHandler(w http.ResponseWriter, r *http.Request) {
+	defaultHost.ReportHandler(w, r)
+}
+
+// ReportHandler - debug HTTP handler for the host report
+func (h *Host) ReportHandler(w http.ResponseWriter, r *http.Request) {
+	report := h.LastReport()
+	if report == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if r != nil && (strings.Contains(r.Header.Get("Accept"), "json") || r.URL.Query().Get("format") == "json") {
+		buf, err := report.Json()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(buf)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(report.Text()))
+}
@@ -0,0 +1,99 @@
+package startup
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var reportStart = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestReport() *Report {
+	return &Report{
+		Env:    "stage",
+		Start:  reportStart,
+		End:    reportStart.Add(time.Second * 2),
+		Status: runtime.NewStatus(http.StatusInternalServerError),
+		Entries: []ReportEntry{
+			{Uri: "urn:startup:good", Order: 1, Status: runtime.NewStatusOK(), Sent: reportStart, Replied: reportStart.Add(time.Millisecond * 10)},
+			{Uri: "urn:startup:depends", Order: 2, Status: runtime.NewStatusError(0, runLocation, errors.New("startup failure error message")), Sent: reportStart, Replied: reportStart.Add(time.Second)},
+			{Uri: "urn:startup:silent", Order: 3, Sent: reportStart},
+		},
+	}
+}
+
+func ExampleReport_Text() {
+	fmt.Printf("%v", newTestReport().Text())
+
+	//Output:
+	//startup [Internal Error] env [stage] start [2024-03-01 12:00:00.000000] duration [2s]
+	//ORDER  URI                  CODE  STATUS          DURATION  SENT                        REPLIED                     ERRORS
+	//1      urn:startup:good     200   OK              10ms      2024-03-01 12:00:00.000000  2024-03-01 12:00:00.010000
+	//2      urn:startup:depends  500   Internal Error  1s        2024-03-01 12:00:00.000000  2024-03-01 12:00:01.000000  startup failure error message
+	//3      urn:startup:silent   94    Not Provided              2024-03-01 12:00:00.000000
+
+}
+
+func ExampleReport_Json() {
+	buf, err := newTestReport().Json()
+	fmt.Printf("test: Json() -> [err:%v]\n%v\n", err, string(buf))
+
+	//Output:
+	//test: Json() -> [err:<nil>]
+	//{"env":"stage","start":"2024-03-01 12:00:00.000000","end":"2024-03-01 12:00:02.000000","duration":"2s","code":500,"status":"Internal Error","entries":[{"uri":"urn:startup:good","order":1,"code":200,"status":"OK","duration":"10ms","sent":"2024-03-01 12:00:00.000000","replied":"2024-03-01 12:00:00.010000","trace":null,"errors":null},{"uri":"urn:startup:depends","order":2,"code":500,"status":"Internal Error","duration":"1s","sent":"2024-03-01 12:00:00.000000","replied":"2024-03-01 12:00:01.000000","trace":["github.com/go-ai-agent/core/runtime/startup/Run"],"errors":["startup failure error message"]},{"uri":"urn:startup:silent","order":3,"code":94,"status":"Not Provided","duration":"","sent":"2024-03-01 12:00:00.000000","replied":"","trace":null,"errors":null}]}
+
+}
+
+func ExampleLastReport() {
+	uri1 := "urn:report:good"
+	uri2 := "urn:report:bad"
+	uri3 := "urn:report:late"
+
+	start = time.Now()
+	defaultHost.dir.Empty()
+
+	c := make(chan Message, 16)
+	Register(uri1, c)
+	go startupGood(c)
+
+	c = make(chan Message, 16)
+	Register(uri2, c)
+	DependsOn(uri2, uri1)
+	go startupBad(c)
+
+	status := Run[runtime.BypassError](time.Second*2, nil)
+	report := LastReport()
+	fmt.Printf("test: Run() -> [status:%v] [report:%v] [env:%v] [entries:%v]\n", status, report.Status, report.Env, len(report.Entries))
+	for _, e := range report.Entries {
+		fmt.Printf("test: LastReport() -> [order:%v] [uri:%v] [status:%v] [sent:%v] [replied:%v]\n", e.Order, e.Uri, e.Status, !e.Sent.IsZero(), e.Duration() > 0)
+	}
+
+	c = make(chan Message, 16)
+	Register(uri3, c)
+	DependsOn(uri1, uri3)
+	go startupGood(c)
+	status = Run[runtime.BypassError](time.Second*2, nil)
+	report = LastReport()
+	fmt.Printf("test: Run() -> [status:%v] [report:%v] [entries:%v]\n", status, report.Status, len(report.Entries))
+	for _, e := range report.Entries {
+		fmt.Printf("test: LastReport() -> [order:%v] [uri:%v] [status:%v]\n", e.Order, e.Uri, e.Status)
+	}
+
+	rec := httptest.NewRecorder()
+	ReportHandler(rec, httptest.NewRequest(http.MethodGet, ReportPath+"?format=json", nil))
+	fmt.Printf("test: ReportHandler() -> [code:%v] [content-type:%v]\n", rec.Code, rec.Header().Get("Content-Type"))
+
+	//Output:
+	//test: Run() -> [status:OK] [report:OK] [env:debug] [entries:2]
+	//test: LastReport() -> [order:1] [uri:urn:report:good] [status:OK] [sent:true] [replied:true]
+	//test: LastReport() -> [order:2] [uri:urn:report:bad] [status:OK] [sent:true] [replied:true]
+	//test: Run() -> [status:OK] [report:OK] [entries:3]
+	//test: LastReport() -> [order:1] [uri:urn:report:late] [status:OK]
+	//test: LastReport() -> [order:2] [uri:urn:report:good] [status:OK]
+	//test: LastReport() -> [order:3] [uri:urn:report:bad] [status:OK]
+	//test: ReportHandler() -> [code:200] [content-type:application/json]
+
+}
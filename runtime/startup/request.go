@@ -0,0 +1,109 @@
+package startup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"github.com/google/uuid"
+	"net/http"
+	"sync"
+)
+
+var requestLocation = PkgUri + "/Request"
+
+// replyTable - pending requests by correlation id
+type replyTable struct {
+	m  map[string]chan Message
+	mu sync.Mutex
+}
+
+func newReplyTable() *replyTable {
+	return &replyTable{m: make(map[string]chan Message)}
+}
+
+func (t *replyTable) add(id string) chan Message {
+	c := make(chan Message, 1)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.m[id] = c
+	return c
+}
+
+func (t *replyTable) remove(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.m, id)
+}
+
+func (t *replyTable) count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.m)
+}
+
+// deliver - route a reply to the pending request, replies with unknown or expired correlation ids are discarded
+func (t *replyTable) deliver(msg Message) {
+	t.mu.Lock()
+	c, ok := t.m[msg.CorrelationId]
+	if ok {
+		delete(t.m, msg.CorrelationId)
+	}
+	t.mu.Unlock()
+	if ok {
+		c <- msg
+	}
+}
+
+// NewCorrelationId - create a new correlation id
+func NewCorrelationId() string {
+	return uuid.New().String()
+}
+
+// Request - send a message and wait for the reply, which is matched by the message correlation id. If the context
+// does not have a deadline, then the request will wait a maximum of 2 seconds.
+func Request(ctx context.Context, to, event string, content []any) (Message, *runtime.Status) {
+	return defaultHost.Request(ctx, to, event, content)
+}
+
+// Request - send a message to a startup uri registered with the host, and wait for the reply
+func (h *Host) Request(ctx context.Context, to, event string, content []any) (Message, *runtime.Status) {
+	if to == "" {
+		return Message{}, runtime.NewStatusError(runtime.StatusInvalidArgument, requestLocation, errors.New("invalid argument: to uri is empty"))
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, maxWait)
+		defer cancel()
+	}
+	id := NewCorrelationId()
+	c := h.replies.add(id)
+	defer h.replies.remove(id)
+	msg := Message{To: to, From: h.name, Event: event, CorrelationId: id, Content: content, ReplyTo: h.replies.deliver}
+	if err := h.dir.SendContext(ctx, msg); err != nil {
+		if ctx.Err() != nil {
+			return Message{}, contextStatus(ctx, to, event)
+		}
+		return Message{}, runtime.NewStatusError(http.StatusInternalServerError, requestLocation, err)
+	}
+	select {
+	case reply := <-c:
+		if reply.Status == nil {
+			return reply, runtime.NewStatusError(runtime.StatusNotProvided, requestLocation, errors.New(fmt.Sprintf("reply status not available: [%v] [%v]", to, event)))
+		}
+		return reply, reply.Status
+	case <-ctx.Done():
+		return Message{}, contextStatus(ctx, to, event)
+	}
+}
+
+// contextStatus - the status of a request whose context is done, a cancelled request is not a time out
+func contextStatus(ctx context.Context, to, event string) *runtime.Status {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return runtime.NewStatusError(runtime.StatusCancelled, requestLocation, errors.New(fmt.Sprintf("request cancelled: [%v] [%v]", to, event)))
+	}
+	return runtime.NewStatusError(runtime.StatusDeadlineExceeded, requestLocation, errors.New(fmt.Sprintf("reply time out: [%v] [%v]", to, event)))
+}
@@ -0,0 +1,89 @@
+package startup
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+func ExampleRequest_error() {
+	uri := "urn:request:silent"
+	defaultHost.dir.Empty()
+
+	_, status := Request(nil, "", PingEvent, nil)
+	fmt.Printf("test: Request(empty-uri) -> [status:%v]\n", status)
+
+	_, status = Request(nil, uri, PingEvent, nil)
+	fmt.Printf("test: Request(not-registered) -> [status:%v]\n", status)
+
+	Register(uri, make(chan Message, 16))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	_, status = Request(ctx, uri, PingEvent, nil)
+	fmt.Printf("test: Request(silent) -> [status:%v] [pending:%v]\n", status, defaultHost.replies.count())
+
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	time.AfterFunc(time.Millisecond*100, cancel2)
+	_, status = Request(ctx2, uri, PingEvent, nil)
+	fmt.Printf("test: Request(cancelled) -> [status:%v] [pending:%v]\n", status, defaultHost.replies.count())
+
+	//Output:
+	//test: Request(empty-uri) -> [status:Invalid Argument [invalid argument: to uri is empty]]
+	//test: Request(not-registered) -> [status:Internal Error [entry not found: [urn:request:silent]]]
+	//test: Request(silent) -> [status:Deadline Exceeded [reply time out: [urn:request:silent] [event:ping]]] [pending:0]
+	//test: Request(cancelled) -> [status:Cancelled [request cancelled: [urn:request:silent] [event:ping]]] [pending:0]
+
+}
+
+func ExampleRequest() {
+	uri := "urn:request:echo"
+	defaultHost.dir.Empty()
+
+	c := make(chan Message, 16)
+	Register(uri, c)
+	go requestEcho(c)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var results []string
+	for _, content := range []string{"slow", "fast"} {
+		wg.Add(1)
+		go func(content string) {
+			defer wg.Done()
+			reply, status := Request(nil, uri, StatusEvent, []any{content})
+			mu.Lock()
+			results = append(results, fmt.Sprintf("[request:%v] [reply:%v] [status:%v]", content, reply.Status.ContentString(), status))
+			mu.Unlock()
+		}(content)
+	}
+	wg.Wait()
+	sort.Strings(results)
+	for _, r := range results {
+		fmt.Printf("test: Request() -> %v\n", r)
+	}
+
+	//Output:
+	//test: Request() -> [request:fast] [reply:fast] [status:OK]
+	//test: Request() -> [request:slow] [reply:slow] [status:OK]
+
+}
+
+// requestEcho - reply to each message concurrently, delaying "slow" requests so that replies arrive out of order
+func requestEcho(c chan Message) {
+	for msg := range c {
+		go func(msg Message) {
+			content := ""
+			if len(msg.Content) > 0 {
+				content, _ = msg.Content[0].(string)
+			}
+			if content == "slow" {
+				time.Sleep(time.Millisecond * 200)
+			}
+			ReplyTo(msg, runtime.NewStatus(http.StatusOK).SetContent(content, false))
+		}(msg)
+	}
+}
@@ -1,53 +1,93 @@
 package startup
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/go-ai-agent/core/runtime"
 	"net/http"
+	"sort"
 	"time"
 )
 
 type messageMap map[string]Message
 
-var runLocation = PkgUri + "/Run"
+func (m messageMap) uri() []string {
+	var uri []string
+	for k := range m {
+		uri = append(uri, k)
+	}
+	sort.Strings(uri)
+	return uri
+}
 
-var directory = NewEntryDirectory()
+var runLocation = PkgUri + "/Run"
 
 // Register - function to register a startup uri
 func Register(uri string, c chan Message) error {
-	if uri == "" {
-		return errors.New("invalid argument: uri is empty")
-	}
-	if c == nil {
-		return errors.New(fmt.Sprintf("invalid argument: channel is nil for [%v]", uri))
-	}
-	registerUnchecked(uri, c)
-	return nil
+	return defaultHost.Register(uri, c)
 }
 
 func registerUnchecked(uri string, c chan Message) error {
-	directory.Add(uri, c)
+	defaultHost.dir.Add(uri, c)
 	return nil
 }
 
+// Unregister - function to unregister a startup uri, the registered channel is closed and any subscriptions
+// are removed. The uri can then be registered again with a new channel.
+func Unregister(uri string) error {
+	return defaultHost.Unregister(uri)
+}
+
+// Watch - function to watch for registered and unregistered startup uris
+func Watch(c chan EntryEvent) (cancel func()) {
+	return defaultHost.Watch(c)
+}
+
+// DependsOn - function to set the startup uris that a startup uri depends on
+func DependsOn(uri string, dependencies ...string) error {
+	return defaultHost.DependsOn(uri, dependencies...)
+}
+
 // Shutdown - startup shutdown
 func Shutdown() {
-	directory.Shutdown()
+	defaultHost.Shutdown()
 }
 
-// Run - templated function to start all registered resources.
+// DeadLetters - messages that could not be delivered to a startup uri
+func DeadLetters() *DeadLetterStore {
+	return defaultHost.DeadLetters()
+}
+
+// Run - templated function to start all registered resources. Resources that have already been started are
+// skipped, so Run can be called again to start resources registered after the initial startup.
 func Run[E runtime.ErrorHandler](duration time.Duration, content ContentMap) (status *runtime.Status) {
 	var e E
+	return defaultHost.run(e, duration, content)
+}
+
+// Run - start all registered resources, using the host error handler
+func (h *Host) Run(duration time.Duration, content ContentMap) *runtime.Status {
+	return h.run(h.handler, duration, content)
+}
+
+func (h *Host) run(e runtime.ErrorHandler, duration time.Duration, content ContentMap) (status *runtime.Status) {
 	var failures []string
-	var count = directory.Count()
 
+	start := time.Now().UTC()
+	cache := NewMessageCache()
+	recorder := newReportRecorder(cache)
+	toSend := h.createToSend(content, recorder.handle)
+	count := len(toSend)
 	if count == 0 {
 		return runtime.NewStatusOK()
 	}
-	cache := NewMessageCache()
-	toSend := createToSend(content, NewMessageCacheHandler(cache))
-	sendMessages(toSend)
+	defer func() {
+		h.mergeLastReport(recorder.build(start, toSend, status))
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+	h.sendMessages(ctx, toSend, recorder)
 	for wait := time.Duration(float64(duration) * 0.25); duration >= 0; duration -= wait {
 		time.Sleep(wait)
 		// Check for completion
@@ -57,24 +97,28 @@ func Run[E runtime.ErrorHandler](duration time.Duration, content ContentMap) (st
 		// Check for failed resources
 		failures = cache.Exclude(StartupEvent, http.StatusOK)
 		if len(failures) == 0 {
-			handleStatus(cache)
+			h.setStarted(toSend)
 			return runtime.NewStatusOK()
 		}
 		break
 	}
-	Shutdown()
+	// Only shutdown the resources of this run, resources started by an earlier run are unaffected
+	h.dir.shutdown(toSend.uri())
 	if len(failures) > 0 {
-		handleErrors[E](failures, cache)
+		handleErrors(e, failures, cache)
 		return runtime.NewStatus(http.StatusInternalServerError)
 	}
 	//return e.Handle("", runLocation, errors.New(fmt.Sprintf("response counts < directory entries [%v] [%v]", cache.Count(), directory.Count()))).SetCode(runtime.StatusDeadlineExceeded)
-	return e.Handle(runtime.NewStatusError(runtime.StatusDeadlineExceeded, runLocation, errors.New(fmt.Sprintf("response counts < directory entries [%v] [%v]", cache.Count(), directory.Count()))), "", "")
+	return e.Handle(runtime.NewStatusError(runtime.StatusDeadlineExceeded, runLocation, errors.New(fmt.Sprintf("response counts < directory entries [%v] [%v]", cache.Count(), count))), "", "")
 }
 
-func createToSend(cm ContentMap, fn MessageHandler) messageMap {
+func (h *Host) createToSend(cm ContentMap, fn MessageHandler) messageMap {
 	m := make(messageMap)
-	for _, k := range directory.Uri() {
-		msg := Message{To: k, From: HostName, Event: StartupEvent, Status: nil, ReplyTo: fn}
+	for _, k := range h.dir.Uri() {
+		if e := h.dir.Get(k); e != nil && e.Started() {
+			continue
+		}
+		msg := Message{To: k, From: h.name, Event: StartupEvent, Status: nil, ReplyTo: fn}
 		if cm != nil {
 			if content, ok := cm[k]; ok {
 				msg.Content = append(msg.Content, content...)
@@ -85,35 +129,83 @@ func createToSend(cm ContentMap, fn MessageHandler) messageMap {
 	return m
 }
 
-func sendMessages(msgs messageMap) {
+func (h *Host) setStarted(msgs messageMap) {
 	for k := range msgs {
-		directory.Send(msgs[k])
+		if e := h.dir.Get(k); e != nil {
+			e.setStarted()
+		}
 	}
 }
 
-func handleErrors[E runtime.ErrorHandler](failures []string, cache *MessageCache) {
-	var e E
-	for _, uri := range failures {
-		msg, err := cache.Get(uri)
-		if err != nil {
-			continue
+// sendMessages - send the messages in dependency order, waiting on full entry channels until the context is done
+func (h *Host) sendMessages(ctx context.Context, msgs messageMap, recorder *reportRecorder) {
+	for _, uri := range h.dependencyOrder(msgs.uri()) {
+		sent := time.Now().UTC()
+		if h.dir.SendContext(ctx, msgs[uri]) == nil {
+			recorder.setSent(uri, sent)
 		}
-		if msg.Status != nil {
-			//.Handle("", msg.Status.Location()[0], msg.Status.Errors()...)
-			e.Handle(runtime.NewStatusError(http.StatusInternalServerError, msg.Status.Location()[0], msg.Status.Errors()...), "", "")
+	}
+}
 
+// DependsOn - set the startup uris that a startup uri depends on, replacing any previous dependencies. Startup
+// messages are sent to dependencies first, and the startup report is in dependency order.
+func (h *Host) DependsOn(uri string, dependencies ...string) error {
+	if uri == "" {
+		return errors.New("invalid argument: uri is empty")
+	}
+	h.depsMu.Lock()
+	defer h.depsMu.Unlock()
+	if len(dependencies) == 0 {
+		delete(h.deps, uri)
+		return nil
+	}
+	h.deps[uri] = append([]string(nil), dependencies...)
+	return nil
+}
+
+// dependencyOrder - order the uris so that dependencies come first, dependencies that are not in the uris are
+// ignored, and otherwise uris are in sorted order. A dependency cycle is broken at the first uri visited.
+func (h *Host) dependencyOrder(uri []string) []string {
+	h.depsMu.RLock()
+	defer h.depsMu.RUnlock()
+	var order []string
+	in := make(map[string]bool)
+	visited := make(map[string]bool)
+	sorted := append([]string(nil), uri...)
+	sort.Strings(sorted)
+	for _, u := range sorted {
+		in[u] = true
+	}
+	var visit func(u string)
+	visit = func(u string) {
+		if visited[u] {
+			return
+		}
+		visited[u] = true
+		deps := append([]string(nil), h.deps[u]...)
+		sort.Strings(deps)
+		for _, d := range deps {
+			if in[d] {
+				visit(d)
+			}
 		}
+		order = append(order, u)
 	}
+	for _, u := range sorted {
+		visit(u)
+	}
+	return order
 }
 
-func handleStatus(cache *MessageCache) {
-	for _, uri := range cache.Uri() {
+// handleErrors - handle the reply status of each failed resource, with all of its locations
+func handleErrors(e runtime.ErrorHandler, failures []string, cache *MessageCache) {
+	for _, uri := range failures {
 		msg, err := cache.Get(uri)
 		if err != nil {
 			continue
 		}
 		if msg.Status != nil {
-			fmt.Printf("startup successful for startup [%v] : %s", uri, msg.Status.Duration())
+			e.Handle(msg.Status, "", runLocation)
 		}
 	}
 }
@@ -21,12 +21,12 @@ func ExampleCreateToSend() {
 	registerUnchecked(none, nil)
 	registerUnchecked(one, nil)
 
-	m := createToSend(nil, nil)
+	m := defaultHost.createToSend(nil, nil)
 	msg := m[none]
 	fmt.Printf("test: createToSend(nil,nil) -> [to:%v] [from:%v]\n", msg.To, msg.From)
 
 	cm := ContentMap{one: []any{credFn}}
-	m = createToSend(cm, nil)
+	m = defaultHost.createToSend(cm, nil)
 	msg = m[one]
 	fmt.Printf("test: createToSend(map,nil) -> [to:%v] [from:%v] [credentials:%v]\n", msg.To, msg.From, AccessCredentials(&msg) != nil)
 
@@ -36,13 +36,120 @@ func ExampleCreateToSend() {
 
 }
 
+func Example_createToSend_started() {
+	one := "/startup/one"
+	two := "/startup/two"
+
+	defaultHost.dir.Empty()
+	registerUnchecked(one, nil)
+	defaultHost.setStarted(defaultHost.createToSend(nil, nil))
+	registerUnchecked(two, nil)
+
+	m := defaultHost.createToSend(nil, nil)
+	fmt.Printf("test: createToSend() -> [count:%v] [one:%v] [two:%v]\n", len(m), m[one].To, m[two].To)
+
+	//Output:
+	//test: createToSend() -> [count:1] [one:] [two:/startup/two]
+
+}
+
+func ExampleUnregister() {
+	uri := "urn:startup:plugin"
+	defaultHost.dir.Empty()
+
+	fmt.Printf("test: Unregister(%v) -> [err:%v]\n", uri, Unregister(uri))
+
+	events := make(chan EntryEvent, 16)
+	cancel := Watch(events)
+	defer cancel()
+
+	c := make(chan Message, 16)
+	Register(uri, c)
+	Subscribe(Subscription{Uri: uri, Pattern: "event:config-changed"})
+	fmt.Printf("test: Unregister(%v) -> [err:%v] [subscriptions:%v]\n", uri, Unregister(uri), defaultHost.broker.Count())
+	_, open := <-c
+	fmt.Printf("test: Unregister(%v) -> [channel-open:%v]\n", uri, open)
+
+	c = make(chan Message, 16)
+	Register(uri, c)
+	defaultHost.setStarted(defaultHost.createToSend(nil, nil))
+	fmt.Printf("test: Register(%v) -> [count:%v] [started:%v]\n", uri, defaultHost.dir.Count(), defaultHost.dir.Get(uri).Started())
+	for i := 0; i < 3; i++ {
+		e := <-events
+		fmt.Printf("test: Watch() -> [event:%v] [uri:%v]\n", e.Event, e.Uri)
+	}
+
+	//Output:
+	//test: Unregister(urn:startup:plugin) -> [err:invalid argument: uri is not registered [urn:startup:plugin]]
+	//test: Unregister(urn:startup:plugin) -> [err:<nil>] [subscriptions:0]
+	//test: Unregister(urn:startup:plugin) -> [channel-open:false]
+	//test: Register(urn:startup:plugin) -> [count:1] [started:true]
+	//test: Watch() -> [event:event:entry-added] [uri:urn:startup:plugin]
+	//test: Watch() -> [event:event:entry-removed] [uri:urn:startup:plugin]
+	//test: Watch() -> [event:event:entry-added] [uri:urn:startup:plugin]
+
+}
+
+func ExampleHost_Run() {
+	good := "urn:run:good"
+	bad := "urn:run:bad"
+	h := NewHost[runtime.BypassError]("urn:run:host")
+
+	goodEvents := make(chan string, 16)
+	c := make(chan Message, 16)
+	h.Register(good, c)
+	go startupRecord(c, nil, goodEvents)
+	fmt.Printf("test: Run() -> [status:%v]\n", h.Run(time.Millisecond*400, nil))
+
+	badEvents := make(chan string, 16)
+	c = make(chan Message, 16)
+	h.Register(bad, c)
+	go startupRecord(c, errors.New("startup failure error message"), badEvents)
+	fmt.Printf("test: Run() -> [status:%v]\n", h.Run(time.Millisecond*400, nil))
+
+	time.Sleep(time.Millisecond * 50)
+	fmt.Printf("test: Run() -> [%v:%v] [%v:%v]\n", good, len(goodEvents), bad, len(badEvents))
+	for n := len(badEvents); n > 0; n-- {
+		fmt.Printf("test: Run() -> [%v] [event:%v]\n", bad, <-badEvents)
+	}
+
+	//Output:
+	//test: Run() -> [status:OK]
+	//test: Run() -> [status:Internal Error]
+	//test: Run() -> [urn:run:good:1] [urn:run:bad:2]
+	//test: Run() -> [urn:run:bad] [event:event:startup]
+	//test: Run() -> [urn:run:bad] [event:event:shutdown]
+
+}
+
+// locationError - error handler printing the locations of a status
+type locationError struct{}
+
+func (locationError) Handle(s *runtime.Status, requestId string, callerLocation string) *runtime.Status {
+	s.AddLocation(callerLocation)
+	fmt.Printf("test: Handle() -> [status:%v] [location:%v]\n", s, s.Location())
+	return s
+}
+
+func Example_handleErrors() {
+	cache := NewMessageCache()
+	cache.Add(Message{From: "urn:run:none", Status: runtime.NewStatus(runtime.StatusInvalidArgument)})
+	cache.Add(Message{From: "urn:run:nested", Status: runtime.NewStatusError(runtime.StatusInvalidArgument, "urn:run:inner", errors.New("nested")).AddLocation("urn:run:outer")})
+	handleErrors(locationError{}, []string{"urn:run:none", "urn:run:nested", "urn:run:missing"}, cache)
+
+	//Output:
+	//test: Handle() -> [status:Invalid Argument] [location:[github.com/go-ai-agent/core/runtime/startup/Run]]
+	//test: Handle() -> [status:Invalid Argument [nested]] [location:[urn:run:inner urn:run:outer github.com/go-ai-agent/core/runtime/startup/Run]]
+
+}
+
 func ExampleStartup_Success() {
 	uri1 := "urn:startup:good"
 	uri2 := "urn:startup:bad"
 	uri3 := "urn:startup:depends"
 
 	start = time.Now()
-	directory.Empty()
+	defaultHost.dir.Empty()
 
 	c := make(chan Message, 16)
 	Register(uri1, c)
@@ -71,7 +178,7 @@ func ExampleStartup_Failure() {
 	uri3 := "urn:startup:depends"
 
 	start = time.Now()
-	directory.Empty()
+	defaultHost.dir.Empty()
 
 	c := make(chan Message, 16)
 	Register(uri1, c)
@@ -141,3 +248,18 @@ func startupDepends(c chan Message, err error) {
 		}
 	}
 }
+
+// startupRecord - record the events received, and reply to a startup message
+func startupRecord(c chan Message, err error, events chan string) {
+	for msg := range c {
+		events <- msg.Event
+		if msg.Event != StartupEvent {
+			continue
+		}
+		if err != nil {
+			ReplyTo(msg, runtime.NewStatusError(0, runLocation, err))
+		} else {
+			ReplyTo(msg, runtime.NewStatusOK())
+		}
+	}
+}
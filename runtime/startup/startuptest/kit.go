@@ -0,0 +1,138 @@
+package startuptest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"github.com/go-ai-agent/core/runtime/startup"
+	"strings"
+	"sync"
+)
+
+// Step - a message received by a resource
+type Step struct {
+	Uri   string
+	Event string
+}
+
+func (s Step) String() string {
+	return s.Uri + " " + s.Event
+}
+
+// Kit - collection of fake resources, registered either with the startup package, or with an isolated host
+type Kit struct {
+	host      *startup.Host
+	resources map[string]*Resource
+	steps     []Step
+	mu        sync.Mutex
+}
+
+// New - create a kit registering resources with the startup package
+func New() *Kit {
+	return &Kit{resources: make(map[string]*Resource)}
+}
+
+// NewIsolated - create a kit registering resources with a new host, not shared with other tests
+func NewIsolated() *Kit {
+	k := New()
+	k.host = startup.NewHost[runtime.BypassError]("")
+	return k
+}
+
+// Host - the isolated host, nil if resources are registered with the startup package
+func (k *Kit) Host() *startup.Host { return k.host }
+
+// Directory - the isolated host directory, nil if resources are registered with the startup package
+func (k *Kit) Directory() *startup.EntryDirectory {
+	if k.host == nil {
+		return nil
+	}
+	return k.host.Directory()
+}
+
+// Add - create, register and start a fake resource
+func (k *Kit) Add(uri string) (*Resource, error) {
+	r := NewResource(uri)
+	r.record = k.record
+	register := startup.Register
+	if k.host != nil {
+		register = k.host.Register
+	}
+	if err := register(uri, r.C()); err != nil {
+		return nil, err
+	}
+	k.mu.Lock()
+	k.resources[uri] = r
+	k.mu.Unlock()
+	r.Start()
+	return r, nil
+}
+
+// Get - a fake resource
+func (k *Kit) Get(uri string) *Resource {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.resources[uri]
+}
+
+// Close - unregister all fake resources, closing the resource channels
+func (k *Kit) Close() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for uri := range k.resources {
+		if k.host != nil {
+			k.host.Unregister(uri)
+		} else {
+			startup.Unregister(uri)
+		}
+		delete(k.resources, uri)
+	}
+}
+
+func (k *Kit) record(uri string, msg startup.Message) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.steps = append(k.steps, Step{Uri: uri, Event: msg.Event})
+}
+
+// Steps - all messages received by all resources, in order
+func (k *Kit) Steps() []Step {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return append([]Step(nil), k.steps...)
+}
+
+// ExpectOrder - verify that the steps were received in order, other steps may be interleaved
+func (k *Kit) ExpectOrder(steps ...Step) error {
+	got := k.Steps()
+	i := 0
+	for _, s := range got {
+		if i < len(steps) && s == steps[i] {
+			i++
+		}
+	}
+	if i == len(steps) {
+		return nil
+	}
+	return errors.New(fmt.Sprintf("step not received in order: [%v] got [%v]", steps[i], joinSteps(got)))
+}
+
+// ExpectEvents - verify the events received by a resource, in order, and with no other events
+func ExpectEvents(r *Resource, events ...string) error {
+	if r == nil {
+		return errors.New("invalid argument: resource is nil")
+	}
+	got := r.Events()
+	if strings.Join(got, ",") != strings.Join(events, ",") {
+		return errors.New(fmt.Sprintf("events mismatch: [%v] want [%v] got [%v]", r.Uri(), strings.Join(events, ","), strings.Join(got, ",")))
+	}
+	return nil
+}
+
+func joinSteps(steps []Step) string {
+	var s []string
+	for _, step := range steps {
+		s = append(s, step.String())
+	}
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,80 @@
+package startuptest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"github.com/go-ai-agent/core/runtime/startup"
+	"net/http"
+	"time"
+)
+
+func ExampleKit() {
+	k := New()
+	defer k.Close()
+
+	good, _ := k.Add("urn:kit:good")
+	slow, _ := k.Add("urn:kit:slow")
+	slow.On(startup.StartupEvent, Delay(time.Millisecond*100, runtime.NewStatusOK()))
+	slow.On(startup.PingEvent, Silence())
+
+	status := startup.Run[runtime.BypassError](time.Second, nil)
+	fmt.Printf("test: Run() -> [status:%v]\n", status)
+
+	status = startup.Ping[runtime.BypassError](nil, good.Uri())
+	fmt.Printf("test: Ping(good) -> [status:%v]\n", status)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	status = startup.Ping[runtime.BypassError](ctx, slow.Uri())
+	fmt.Printf("test: Ping(slow) -> [status:%v]\n", status)
+
+	startup.Shutdown()
+	good.Wait(startup.ShutdownEvent, 1, time.Second)
+	slow.Wait(startup.ShutdownEvent, 1, time.Second)
+	fmt.Printf("test: ExpectEvents(good) -> [err:%v]\n", ExpectEvents(good, startup.StartupEvent, startup.PingEvent, startup.ShutdownEvent))
+	fmt.Printf("test: ExpectEvents(slow) -> [err:%v]\n", ExpectEvents(slow, startup.StartupEvent, startup.ShutdownEvent))
+	fmt.Printf("test: ExpectOrder() -> [err:%v]\n", k.ExpectOrder(Step{good.Uri(), startup.PingEvent}, Step{slow.Uri(), startup.PingEvent}))
+	fmt.Printf("test: ExpectOrder(invalid) -> [err:%v]\n", k.ExpectOrder(Step{slow.Uri(), startup.PingEvent}, Step{good.Uri(), startup.PingEvent}) != nil)
+
+	//Output:
+	//test: Run() -> [status:OK]
+	//test: Ping(good) -> [status:OK]
+	//test: Ping(slow) -> [status:Deadline Exceeded [ping response time out: [urn:kit:slow]]]
+	//test: ExpectEvents(good) -> [err:<nil>]
+	//test: ExpectEvents(slow) -> [err:events mismatch: [urn:kit:slow] want [event:startup,event:shutdown] got [event:startup,event:ping,event:shutdown]]
+	//test: ExpectOrder() -> [err:<nil>]
+	//test: ExpectOrder(invalid) -> [err:true]
+
+}
+
+func ExampleNewIsolated() {
+	k := NewIsolated()
+	defer k.Close()
+
+	r, _ := k.Add("urn:kit:isolated")
+	r.On(startup.StartupEvent, Reply(runtime.NewStatus(http.StatusServiceUnavailable)), Reply(runtime.NewStatusError(http.StatusInternalServerError, "location", errors.New("startup failure"))))
+
+	replies := make(chan startup.Message, 16)
+	for i := 0; i < 3; i++ {
+		k.Directory().Send(startup.Message{To: r.Uri(), From: "urn:kit:test", Event: startup.StartupEvent, ReplyTo: func(msg startup.Message) { replies <- msg }})
+		msg := <-replies
+		fmt.Printf("test: Send(%v) -> [from:%v] [status:%v]\n", i, msg.From, msg.Status)
+	}
+	fmt.Printf("test: Count() -> [isolated:%v] [startup:%v]\n", k.Directory().Count(), startup.Ping[runtime.BypassError](nil, r.Uri()).Code())
+
+	status := k.Host().Run(time.Second, nil)
+	fmt.Printf("test: Run() -> [status:%v] [report:%v]\n", status, k.Host().LastReport().Status)
+	status = k.Host().Ping(nil, r.Uri())
+	fmt.Printf("test: Ping() -> [status:%v]\n", status)
+
+	//Output:
+	//test: Send(0) -> [from:urn:kit:isolated] [status:Service Unavailable]
+	//test: Send(1) -> [from:urn:kit:isolated] [status:Internal Error [startup failure]]
+	//test: Send(2) -> [from:urn:kit:isolated] [status:Internal Error [startup failure]]
+	//test: Count() -> [isolated:1] [startup:500]
+	//test: Run() -> [status:Internal Error] [report:Internal Error]
+	//test: Ping() -> [status:OK]
+
+}
@@ -0,0 +1,138 @@
+package startuptest
+
+import (
+	"github.com/go-ai-agent/core/runtime"
+	"github.com/go-ai-agent/core/runtime/startup"
+	"sync"
+	"time"
+)
+
+// Behavior - scripted response of a fake resource to a message
+type Behavior struct {
+	Status *runtime.Status
+	Delay  time.Duration
+	Silent bool
+}
+
+// Reply - reply immediately with a status
+func Reply(status *runtime.Status) Behavior {
+	return Behavior{Status: status}
+}
+
+// Delay - reply with a status after a delay
+func Delay(d time.Duration, status *runtime.Status) Behavior {
+	return Behavior{Status: status, Delay: d}
+}
+
+// Silence - do not reply
+func Silence() Behavior {
+	return Behavior{Silent: true}
+}
+
+// Resource - fake startup resource. Messages are processed in order, and the behaviors scripted for an event are
+// applied in order, with the last behavior repeated. Events without a script are replied to with StatusOK.
+type Resource struct {
+	uri      string
+	c        chan startup.Message
+	script   map[string][]Behavior
+	received []startup.Message
+	record   func(uri string, msg startup.Message)
+	mu       sync.Mutex
+}
+
+// NewResource - create a fake resource
+func NewResource(uri string) *Resource {
+	return &Resource{uri: uri, c: make(chan startup.Message, 16), script: make(map[string][]Behavior)}
+}
+
+func (r *Resource) Uri() string { return r.uri }
+
+// C - the resource channel
+func (r *Resource) C() chan startup.Message { return r.c }
+
+// On - script the behaviors for an event
+func (r *Resource) On(event string, b ...Behavior) *Resource {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.script[event] = append(r.script[event], b...)
+	return r
+}
+
+// Received - all messages received
+func (r *Resource) Received() []startup.Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]startup.Message(nil), r.received...)
+}
+
+// Events - the events of all messages received, in order
+func (r *Resource) Events() []string {
+	var events []string
+	for _, msg := range r.Received() {
+		events = append(events, msg.Event)
+	}
+	return events
+}
+
+// Count - number of messages received for an event
+func (r *Resource) Count(event string) int {
+	count := 0
+	for _, msg := range r.Received() {
+		if msg.Event == event {
+			count++
+		}
+	}
+	return count
+}
+
+// Start - process messages until the resource channel is closed
+func (r *Resource) Start() {
+	go r.run()
+}
+
+func (r *Resource) run() {
+	for msg := range r.c {
+		b := r.next(msg)
+		if b.Silent {
+			continue
+		}
+		if b.Delay > 0 {
+			time.Sleep(b.Delay)
+		}
+		status := b.Status
+		if status == nil {
+			status = runtime.NewStatusOK()
+		}
+		startup.ReplyTo(msg, status)
+	}
+}
+
+func (r *Resource) next(msg startup.Message) Behavior {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received = append(r.received, msg)
+	if r.record != nil {
+		r.record(r.uri, msg)
+	}
+	s := r.script[msg.Event]
+	if len(s) == 0 {
+		return Behavior{}
+	}
+	b := s[0]
+	if len(s) > 1 {
+		r.script[msg.Event] = s[1:]
+	}
+	return b
+}
+
+// Wait - wait until a number of messages have been received for an event, returns false on a timeout
+func (r *Resource) Wait(event string, count int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for r.Count(event) < count {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(time.Millisecond * 5)
+	}
+	return true
+}
@@ -13,6 +13,7 @@ var (
 	PkgUri     = reflect.TypeOf(any(pkg{})).PkgPath()
 	pkgPath    = runtime.PathFromUri(PkgUri)
 	StatusPath = "/startup/status"
+	ReportPath = "/startup/report"
 )
 
 var StatusRequest = newStatusRequest()
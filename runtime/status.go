@@ -27,10 +27,10 @@ const (
 
 	/*
 		StatusOK                 = codes.OK                 // Not an error; returned on success.
-		StatusCancelled          = codes.Canceled           // The operation was cancelled, typically by the caller.
 		StatusUnknown            = codes.Unknown            // Unknown error. For example, this error may be returned when a Status value received from another address space belongs to an error space that is not known in this address space. Also errors raised by APIs that do not return enough error information may be converted to this error.
 	*/
 
+	StatusCancelled        = 1 //codes.Canceled           // The operation was cancelled, typically by the caller.
 	StatusInvalidArgument  = 3 //codes.InvalidArgument    // The client specified an invalid argument. Note that this differs from FAILED_PRECONDITION. INVALID_ARGUMENT indicates arguments that are problematic regardless of the state of the system (e.g., a malformed file name).
 	StatusDeadlineExceeded = 4 //codes.DeadlineExceeded   // The deadline expired before the operation could complete. For operations that change the state of the system, this error may be returned even if the operation has completed successfully. For example, a successful response from a server could have been delayed long
 
@@ -307,6 +307,8 @@ func (s *Status) Description() string {
 		return "Deadline Exceeded"
 	case StatusInvalidArgument:
 		return "Invalid Argument"
+	case StatusCancelled:
+		return "Cancelled"
 	case StatusHaveContent:
 		return "Content Available"
 
@@ -335,8 +337,6 @@ func (s *Status) Description() string {
 
 		// Unmapped
 		/*
-			case StatusCancelled:
-				return "The operation was cancelled, typically by the caller"
 			case StatusUnknown:
 				return "Unknown error" // For example, this error may be returned when a Status value received from another address space belongs to an error space that is not known in this address space. Also errors raised by APIs that do not return enough error information may be converted to this error."
 			case StatusAlreadyExists:
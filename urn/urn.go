@@ -0,0 +1,214 @@
+package urn
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+	"strings"
+)
+
+const (
+	Scheme       = "urn"
+	Prefix       = Scheme + ":"
+	NssDelimiter = ":"
+	OriginSep    = "."
+
+	maxNidLength = 32
+)
+
+// Urn - uniform resource name, as defined by RFC 8141: urn:<nid>:<nss>[?+<r-component>][?=<q-component>][#<f-component>]
+type Urn struct {
+	Nid        string
+	Nss        string
+	RComponent string
+	QComponent string
+	FComponent string
+}
+
+// Parse - parse a urn. The namespace identifier may also contain ".", to support the origin urns created by
+// runtime.OriginUrn, use Valid for strict RFC 8141 validation.
+func Parse(s string) (Urn, error) {
+	var u Urn
+
+	if len(s) < len(Prefix) || !strings.EqualFold(s[:len(Prefix)], Prefix) {
+		return u, errors.New(fmt.Sprintf("invalid argument: urn scheme is missing [%v]", s))
+	}
+	rest := s[len(Prefix):]
+	if i := strings.Index(rest, "#"); i != -1 {
+		u.FComponent = rest[i+1:]
+		rest = rest[:i]
+	}
+	if i := strings.Index(rest, "?="); i != -1 {
+		u.QComponent = rest[i+2:]
+		rest = rest[:i]
+	}
+	if i := strings.Index(rest, "?+"); i != -1 {
+		u.RComponent = rest[i+2:]
+		rest = rest[:i]
+	}
+	i := strings.Index(rest, NssDelimiter)
+	if i == -1 {
+		return u, errors.New(fmt.Sprintf("invalid argument: urn namespace specific string is missing [%v]", s))
+	}
+	u.Nid = rest[:i]
+	u.Nss = rest[i+1:]
+	if err := validNid(u.Nid, true); err != nil {
+		return u, errors.New(fmt.Sprintf("invalid argument: %v [%v]", err, s))
+	}
+	if err := validNss(u.Nss); err != nil {
+		return u, errors.New(fmt.Sprintf("invalid argument: %v [%v]", err, s))
+	}
+	return u, nil
+}
+
+// New - create a urn from a namespace identifier and namespace specific segments, joined by ":"
+func New(nid string, segments ...string) Urn {
+	return Urn{Nid: nid, Nss: strings.Join(segments, NssDelimiter)}
+}
+
+// NewOrigin - create a urn in the runtime.OriginUrn format, urn:<nid>.<region>.<zone>:<nss>.<resource>
+func NewOrigin(nid, nss, resource string) Urn {
+	o := runtime.GetOrigin()
+	return Urn{Nid: strings.Join([]string{nid, o.Region, o.Zone}, OriginSep), Nss: nss + OriginSep + resource}
+}
+
+// FromUri - create a urn from a resource uri, such as a startup directory key
+func FromUri(uri string) (Urn, error) {
+	return Parse(uri)
+}
+
+// Uri - the normalized urn without components, for use as a resource uri, such as a startup directory key
+func (u Urn) Uri() string {
+	n := u.Normalize()
+	return Prefix + n.Nid + NssDelimiter + n.Nss
+}
+
+func (u Urn) String() string {
+	s := Prefix + u.Nid + NssDelimiter + u.Nss
+	if u.RComponent != "" {
+		s += "?+" + u.RComponent
+	}
+	if u.QComponent != "" {
+		s += "?=" + u.QComponent
+	}
+	if u.FComponent != "" {
+		s += "#" + u.FComponent
+	}
+	return s
+}
+
+// Valid - strict RFC 8141 validation
+func (u Urn) Valid() error {
+	if err := validNid(u.Nid, false); err != nil {
+		return err
+	}
+	return validNss(u.Nss)
+}
+
+// Segments - the namespace specific string split on ":"
+func (u Urn) Segments() []string {
+	return strings.Split(u.Nss, NssDelimiter)
+}
+
+// Origin - the namespace identifier, region and zone of an origin urn
+func (u Urn) Origin() (nid, region, zone string) {
+	t := strings.SplitN(u.Nid, OriginSep, 3)
+	for len(t) < 3 {
+		t = append(t, "")
+	}
+	return t[0], t[1], t[2]
+}
+
+// Resource - the namespace specific string and resource of an origin urn
+func (u Urn) Resource() (nss, resource string) {
+	i := strings.Index(u.Nss, OriginSep)
+	if i == -1 {
+		return u.Nss, ""
+	}
+	return u.Nss[:i], u.Nss[i+1:]
+}
+
+// Normalize - lower case the namespace identifier, and upper case percent-encoded hex digits in the namespace
+// specific string, as required for RFC 8141 equivalence
+func (u Urn) Normalize() Urn {
+	u.Nid = strings.ToLower(u.Nid)
+	u.Nss = normalizePercent(u.Nss)
+	return u
+}
+
+// Equal - RFC 8141 equivalence, the r, q and f components are not compared
+func (u Urn) Equal(v Urn) bool {
+	return u.Uri() == v.Uri()
+}
+
+// Compare - compare the normalized urns, returning -1, 0 or 1
+func (u Urn) Compare(v Urn) int {
+	return strings.Compare(u.Uri(), v.Uri())
+}
+
+func normalizePercent(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+	b := []byte(s)
+	for i := 0; i < len(b); i++ {
+		if b[i] == '%' && i+2 < len(b) {
+			b[i+1] = upperHex(b[i+1])
+			b[i+2] = upperHex(b[i+2])
+			i += 2
+		}
+	}
+	return string(b)
+}
+
+func upperHex(c byte) byte {
+	if c >= 'a' && c <= 'f' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+func validNid(nid string, origin bool) error {
+	if len(nid) < 2 || len(nid) > maxNidLength && !origin {
+		return errors.New(fmt.Sprintf("urn namespace identifier length is invalid [%v]", nid))
+	}
+	if nid[0] == '-' || nid[len(nid)-1] == '-' {
+		return errors.New(fmt.Sprintf("urn namespace identifier cannot start or end with a hyphen [%v]", nid))
+	}
+	for _, c := range nid {
+		if isAlphaNum(c) || c == '-' || (origin && c == '.') {
+			continue
+		}
+		return errors.New(fmt.Sprintf("urn namespace identifier contains an invalid character [%v]", nid))
+	}
+	return nil
+}
+
+func validNss(nss string) error {
+	if nss == "" {
+		return errors.New("urn namespace specific string is empty")
+	}
+	for i := 0; i < len(nss); i++ {
+		c := nss[i]
+		if c == '%' {
+			if i+2 >= len(nss) || !isHex(nss[i+1]) || !isHex(nss[i+2]) {
+				return errors.New(fmt.Sprintf("urn namespace specific string percent-encoding is invalid [%v]", nss))
+			}
+			i += 2
+			continue
+		}
+		if isAlphaNum(rune(c)) || strings.IndexByte("-._~!$&'()*+,;=:@/", c) != -1 {
+			continue
+		}
+		return errors.New(fmt.Sprintf("urn namespace specific string contains an invalid character [%v]", nss))
+	}
+	return nil
+}
+
+func isAlphaNum(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
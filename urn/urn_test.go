@@ -0,0 +1,67 @@
+package urn
+
+import (
+	"fmt"
+	"github.com/go-ai-agent/core/runtime"
+)
+
+func ExampleParse() {
+	s := "URN:Example:a%2fb:c?+resolve?=query#frag"
+	u, err := Parse(s)
+	fmt.Printf("test: Parse(%v) -> [err:%v] [nid:%v] [nss:%v] [r:%v] [q:%v] [f:%v] [segments:%v]\n", s, err, u.Nid, u.Nss, u.RComponent, u.QComponent, u.FComponent, u.Segments())
+	fmt.Printf("test: String() -> %v\n", u)
+	fmt.Printf("test: Uri() -> %v\n", u.Uri())
+
+	for _, s = range []string{"urn:startup:good", "http://host/path", "urn:startup", "urn:-bad:nss", "urn:ab:a%zz", "urn:ab:a b"} {
+		_, err = Parse(s)
+		fmt.Printf("test: Parse(%v) -> [err:%v]\n", s, err)
+	}
+
+	//Output:
+	//test: Parse(URN:Example:a%2fb:c?+resolve?=query#frag) -> [err:<nil>] [nid:Example] [nss:a%2fb:c] [r:resolve] [q:query] [f:frag] [segments:[a%2fb c]]
+	//test: String() -> urn:Example:a%2fb:c?+resolve?=query#frag
+	//test: Uri() -> urn:example:a%2Fb:c
+	//test: Parse(urn:startup:good) -> [err:<nil>]
+	//test: Parse(http://host/path) -> [err:invalid argument: urn scheme is missing [http://host/path]]
+	//test: Parse(urn:startup) -> [err:invalid argument: urn namespace specific string is missing [urn:startup]]
+	//test: Parse(urn:-bad:nss) -> [err:invalid argument: urn namespace identifier cannot start or end with a hyphen [-bad] [urn:-bad:nss]]
+	//test: Parse(urn:ab:a%zz) -> [err:invalid argument: urn namespace specific string percent-encoding is invalid [a%zz] [urn:ab:a%zz]]
+	//test: Parse(urn:ab:a b) -> [err:invalid argument: urn namespace specific string contains an invalid character [a b] [urn:ab:a b]]
+
+}
+
+func ExampleUrn_Equal() {
+	u1, _ := Parse("urn:Example:a%2fb?=q1")
+	u2, _ := Parse("URN:EXAMPLE:a%2Fb#f")
+	u3, _ := Parse("urn:example:A%2Fb")
+
+	fmt.Printf("test: Equal(u1,u2) -> %v\n", u1.Equal(u2))
+	fmt.Printf("test: Equal(u1,u3) -> %v\n", u1.Equal(u3))
+	fmt.Printf("test: Compare(u1,u3) -> %v\n", u1.Compare(u3))
+
+	//Output:
+	//test: Equal(u1,u2) -> true
+	//test: Equal(u1,u3) -> false
+	//test: Compare(u1,u3) -> 1
+
+}
+
+func ExampleNewOrigin() {
+	u := NewOrigin("postgresql", "insert", "query-test-resource.prod")
+	s := runtime.OriginUrn("postgresql", "insert", "query-test-resource.prod")
+	fmt.Printf("test: NewOrigin() -> [urn:%v] [equal:%v] [valid:%v]\n", u, u.String() == s, u.Valid())
+
+	u2, err := FromUri(s)
+	nid, region, zone := u2.Origin()
+	nss, resource := u2.Resource()
+	fmt.Printf("test: FromUri() -> [err:%v] [nid:%v] [region:%v] [zone:%v] [nss:%v] [resource:%v]\n", err, nid, region, zone, nss, resource)
+
+	u3 := New("startup", "cache", "redis")
+	fmt.Printf("test: New() -> [uri:%v] [valid:%v]\n", u3.Uri(), u3.Valid())
+
+	//Output:
+	//test: NewOrigin() -> [urn:urn:postgresql.region.zone:insert.query-test-resource.prod] [equal:true] [valid:urn namespace identifier contains an invalid character [postgresql.region.zone]]
+	//test: FromUri() -> [err:<nil>] [nid:postgresql] [region:region] [zone:zone] [nss:insert] [resource:query-test-resource.prod]
+	//test: New() -> [uri:urn:startup:cache:redis] [valid:<nil>]
+
+}